@@ -0,0 +1,157 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider/inventory"
+)
+
+// listOptions holds the flags shared by the "list" and "describe" subcommands.
+var listOptions struct {
+	output        string
+	labels        []string
+	powerState    string
+	allocatedFlag string
+}
+
+func init() {
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the machines managed by this provider",
+		Args:  cobra.NoArgs,
+		RunE:  runList,
+	}
+
+	describeCmd := &cobra.Command{
+		Use:   "describe <machine-id>",
+		Short: "Describe a single machine managed by this provider",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runDescribe,
+	}
+
+	for _, cmd := range []*cobra.Command{listCmd, describeCmd} {
+		cmd.Flags().StringVarP(&listOptions.output, "output", "o", "table", "Output format. One of: table|json|yaml|wide.")
+	}
+
+	listCmd.Flags().StringSliceVar(&listOptions.labels, "label", nil, "Filter by label, e.g. --label=key=value. Can be repeated.")
+	listCmd.Flags().StringVar(&listOptions.powerState, "power-state", "", "Filter by power state.")
+	listCmd.Flags().StringVar(&listOptions.allocatedFlag, "allocated", "", "Filter by allocation status (true|false).")
+
+	rootCmd.AddCommand(listCmd, describeCmd)
+}
+
+func runList(cmd *cobra.Command, _ []string) error {
+	lister, err := newInventoryLister(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	filter, err := parseListFilter()
+	if err != nil {
+		return err
+	}
+
+	machines, err := inventory.List(cmd.Context(), lister, filter)
+	if err != nil {
+		return err
+	}
+
+	return printMachines(os.Stdout, machines, listOptions.output)
+}
+
+func runDescribe(cmd *cobra.Command, args []string) error {
+	lister, err := newInventoryLister(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	machine, err := lister.Describe(cmd.Context(), args[0])
+	if err != nil {
+		return err
+	}
+
+	return printMachines(os.Stdout, []inventory.Machine{machine}, listOptions.output)
+}
+
+func parseListFilter() (inventory.Filter, error) {
+	filter := inventory.Filter{
+		PowerState: listOptions.powerState,
+		Labels:     map[string]string{},
+	}
+
+	for _, label := range listOptions.labels {
+		k, v, ok := strings.Cut(label, "=")
+		if !ok {
+			return inventory.Filter{}, fmt.Errorf("invalid --label %q, expected key=value", label)
+		}
+
+		filter.Labels[k] = v
+	}
+
+	switch listOptions.allocatedFlag {
+	case "":
+	case "true":
+		allocated := true
+		filter.Allocated = &allocated
+	case "false":
+		allocated := false
+		filter.Allocated = &allocated
+	default:
+		return inventory.Filter{}, fmt.Errorf("invalid --allocated %q, expected true or false", listOptions.allocatedFlag)
+	}
+
+	return filter, nil
+}
+
+func printMachines(w *os.File, machines []inventory.Machine, format string) error {
+	switch format {
+	case "json":
+		return encodeJSON(w, machines)
+	case "yaml":
+		return encodeYAML(w, machines)
+	case "table", "wide":
+		return printTable(w, machines, format == "wide")
+	default:
+		return fmt.Errorf("unknown output format %q, expected one of: table|json|yaml|wide", format)
+	}
+}
+
+func printTable(w *os.File, machines []inventory.Machine, wide bool) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	defer tw.Flush() //nolint:errcheck
+
+	if wide {
+		fmt.Fprintln(tw, "ID\tPOWER STATE\tBOOT METHOD\tBMC ADDRESS\tALLOCATED\tINSTALLED\tLABELS")
+	} else {
+		fmt.Fprintln(tw, "ID\tPOWER STATE\tBOOT METHOD\tALLOCATED\tINSTALLED")
+	}
+
+	for _, m := range machines {
+		if wide {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%t\t%t\t%s\n", m.ID, m.PowerState, m.BootMethod, m.BMCAddress, m.Allocated, m.Installed, formatLabels(m.Labels))
+		} else {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%t\t%t\n", m.ID, m.PowerState, m.BootMethod, m.Allocated, m.Installed)
+		}
+	}
+
+	return nil
+}
+
+func formatLabels(labels map[string]string) string {
+	parts := make([]string, 0, len(labels))
+
+	for k, v := range labels {
+		parts = append(parts, k+"="+v)
+	}
+
+	return strings.Join(parts, ",")
+}
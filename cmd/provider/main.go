@@ -11,6 +11,7 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 
 	"github.com/siderolabs/talos-metal-agent/pkg/config"
@@ -20,8 +21,13 @@ import (
 
 	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/constants"
 	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider"
+	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider/bmc"
+	_ "github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider/bmc/drivers/dell"    // register the Dell vendor driver
+	_ "github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider/bmc/drivers/generic" // register the generic vendor driver
 	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider/bmc/pxe"
+	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider/inventory"
 	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider/ipxe"
+	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider/machineconfig"
 	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider/meta"
 	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/version"
 )
@@ -48,10 +54,58 @@ var rootCmd = &cobra.Command{
 
 		defer logger.Sync() //nolint:errcheck
 
+		if err = parseBMCDriverFlags(); err != nil {
+			return fmt.Errorf("failed to parse BMC driver flags: %w", err)
+		}
+
+		if providerOptions.BootMethod, err = bmc.ParseBootMethod(bootMethod); err != nil {
+			return fmt.Errorf("failed to parse --boot-method: %w", err)
+		}
+
 		return run(cmd.Context(), logger)
 	},
 }
 
+// bootMethod backs the --boot-method flag; it is parsed into providerOptions.BootMethod so that
+// an invalid value is rejected before the provider starts.
+var bootMethod string
+
+// bmcDriver and bmcDriverOverrides back the --bmc-driver and --bmc-driver-overrides flags; they
+// are parsed into providerOptions.BMCDriver/BMCDriverOverrides in parseBMCDriverFlags so that
+// invalid vendor IDs are rejected before the provider starts.
+var (
+	bmcDriver          string
+	bmcDriverOverrides []string
+)
+
+// parseBMCDriverFlags validates --bmc-driver and --bmc-driver-overrides and populates
+// providerOptions with the parsed values.
+func parseBMCDriverFlags() error {
+	driver, err := bmc.ParseVendorID(bmcDriver)
+	if err != nil {
+		return err
+	}
+
+	providerOptions.BMCDriver = driver
+	providerOptions.BMCDriverOverrides = make(map[string]bmc.VendorID, len(bmcDriverOverrides))
+
+	for _, override := range bmcDriverOverrides {
+		machineID, vendor, ok := strings.Cut(override, "=")
+		if !ok {
+			return fmt.Errorf("invalid --bmc-driver-overrides entry %q, expected <machine-id>=<vendor>", override)
+		}
+
+		vendorID, err := bmc.ParseVendorID(vendor)
+		if err != nil {
+			return fmt.Errorf("invalid --bmc-driver-overrides entry %q: %w", override, err)
+		}
+
+		providerOptions.BMCDriverOverrides[machineID] = vendorID
+	}
+
+	return nil
+}
+
 func initLogger() (*zap.Logger, error) {
 	var loggerConfig zap.Config
 
@@ -68,6 +122,63 @@ func initLogger() (*zap.Logger, error) {
 }
 
 func run(ctx context.Context, logger *zap.Logger) error {
+	metrics, shutdownTracing, err := setUpObservability(ctx, logger)
+	if err != nil {
+		return fmt.Errorf("failed to set up observability: %w", err)
+	}
+
+	defer shutdownTracing(context.Background()) //nolint:errcheck
+
+	providerOptions.Observability.Metrics = metrics
+
+	vendorCache, err := bmc.NewVendorCache(providerOptions.BMCVendorCacheFile)
+	if err != nil {
+		return fmt.Errorf("failed to load BMC vendor cache: %w", err)
+	}
+
+	providerOptions.BMCVendorCache = vendorCache
+	providerOptions.BMCRegistry = bmc.DefaultRegistry
+
+	vmBooter, vmSigner, err := setUpVirtualMedia(ctx, logger, metrics)
+	if err != nil {
+		return fmt.Errorf("failed to set up virtual media boot: %w", err)
+	}
+
+	providerOptions.VirtualMediaBooter = vmBooter
+	providerOptions.VirtualMediaSigner = vmSigner
+
+	omniClient, err := connectOmniClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to Omni API: %w", err)
+	}
+
+	// provider.New reuses this same client for its own COSI state access instead of opening a
+	// second connection; the inventory.Lister below is built from it for the same reason.
+	providerOptions.OmniClient = omniClient
+	providerOptions.InventoryLister = inventory.NewClient(omniClient.Omni().State(), providerResourceNamespace)
+
+	rbacInterceptors, err := setUpRBAC(logger)
+	if err != nil {
+		return fmt.Errorf("failed to set up RBAC: %w", err)
+	}
+
+	providerOptions.RBAC.Interceptors = rbacInterceptors
+
+	if providerOptions.MachineConfigFile != "" {
+		machineConfigStore, err := machineconfig.NewStore(providerOptions.MachineConfigFile)
+		if err != nil {
+			return fmt.Errorf("failed to load machine config file: %w", err)
+		}
+
+		go func() {
+			if err := machineConfigStore.Watch(ctx, logger); err != nil {
+				logger.Error("machine config file watcher stopped", zap.Error(err))
+			}
+		}()
+
+		providerOptions.MachineConfigStore = machineConfigStore
+	}
+
 	prov := provider.New(providerOptions, logger)
 
 	if err := prov.Run(ctx); err != nil {
@@ -174,4 +285,45 @@ func init() {
 		"The port to connect to Redfish.")
 	rootCmd.Flags().BoolVar(&providerOptions.Redfish.SetBootSourceOverrideMode, "redfish-set-boot-source-override-mode", provider.DefaultOptions.Redfish.SetBootSourceOverrideMode,
 		"Set the boot source override mode field when using Redfish for power management. Some Redfish implementations require this field to be unset.")
+
+	rootCmd.Flags().StringVar(&bmcDriver, "bmc-driver", string(bmc.VendorAuto),
+		fmt.Sprintf("The BMC vendor driver to use for Redfish power management. Valid values are: %v. "+
+			"If set to %q, the vendor is autodetected by probing the Redfish service root.", []bmc.VendorID{
+			bmc.VendorAuto, bmc.VendorGeneric, bmc.VendorDell, bmc.VendorSupermicro, bmc.VendorHPE, bmc.VendorLenovo,
+		}, bmc.VendorAuto))
+	rootCmd.Flags().StringSliceVar(&bmcDriverOverrides, "bmc-driver-overrides", nil,
+		"Comma separated list of <machine-id>=<vendor> pairs overriding the BMC vendor driver for specific machines, "+
+			"e.g. --bmc-driver-overrides=machine-1=dell,machine-2=supermicro")
+	rootCmd.Flags().StringVar(&providerOptions.BMCVendorCacheFile, "bmc-vendor-cache-file", provider.DefaultOptions.BMCVendorCacheFile,
+		"Path to a file used to persist the BMC vendor detected for each machine, so that power operations after the "+
+			"first skip re-probing Redfish. If not set, detected vendors are cached in memory only and are lost on restart.")
+
+	rootCmd.Flags().StringVar(&bootMethod, "boot-method", string(bmc.BootMethodIPXE),
+		fmt.Sprintf("The method used to boot machines into agent/Talos mode. Valid values are: %v. "+
+			"Can be overridden per machine with the %q label.", []bmc.BootMethod{
+			bmc.BootMethodIPXE, bmc.BootMethodPXE, bmc.BootMethodVirtualMedia,
+		}, bmc.BootMethodLabel))
+
+	// RBAC options
+	rootCmd.Flags().BoolVar(&providerOptions.RBAC.Enabled, "rbac-enabled", provider.DefaultOptions.RBAC.Enabled,
+		"Enable role-based access control on the provider's gRPC/HTTP API.")
+	rootCmd.Flags().StringVar(&providerOptions.RBAC.PolicyFile, "rbac-policy-file", provider.DefaultOptions.RBAC.PolicyFile,
+		"Path to a YAML file mapping subjects to roles to verbs to resources. Required if --rbac-enabled is set.")
+	rootCmd.Flags().BoolVar(&providerOptions.RBAC.Enforce, "rbac-enforce", provider.DefaultOptions.RBAC.Enforce,
+		"Reject requests denied by the RBAC policy. When false, denied requests are logged but still allowed through, "+
+			"to let a new policy be validated against real traffic before it is enforced.")
+
+	// Observability options
+	rootCmd.Flags().StringVar(&providerOptions.Observability.OTLPEndpoint, "otlp-endpoint", provider.DefaultOptions.Observability.OTLPEndpoint,
+		"The OTLP gRPC endpoint to export traces to. Tracing is disabled if not set.")
+	rootCmd.Flags().StringSliceVar(&providerOptions.Observability.OTLPHeaders, "otlp-headers", provider.DefaultOptions.Observability.OTLPHeaders,
+		"Comma separated list of key=value headers to send with every OTLP export.")
+	rootCmd.Flags().Float64Var(&providerOptions.Observability.TraceSampleRatio, "trace-sample-ratio", provider.DefaultOptions.Observability.TraceSampleRatio,
+		"Fraction of traces to sample, between 0 and 1.")
+	rootCmd.Flags().StringVar(&providerOptions.Observability.MetricsListenAddress, "metrics-listen-address", provider.DefaultOptions.Observability.MetricsListenAddress,
+		"The IP address and port to serve Prometheus metrics on, e.g. \":9090\".")
+
+	rootCmd.Flags().StringVar(&providerOptions.MachineConfigFile, "machine-config-file", provider.DefaultOptions.MachineConfigFile,
+		"Path to a YAML file of per-machine overrides (by label, id, or manufacturer) for boot method, boot-from-disk method, "+
+			"Redfish flags, wipe strategy, minimum reboot interval, and BMC vendor driver. Watched and hot-reloaded on edit.")
 }
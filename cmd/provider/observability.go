@@ -0,0 +1,46 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/zap"
+
+	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider/observability"
+)
+
+// setUpObservability initializes OpenTelemetry tracing, registers the provider's Prometheus
+// collectors, and starts the "/metrics" server if --metrics-listen-address is set. The returned
+// shutdown function must be called before the process exits to flush pending spans.
+func setUpObservability(ctx context.Context, logger *zap.Logger) (*observability.Metrics, func(context.Context) error, error) {
+	shutdownTracing, err := observability.InitTracing(ctx, observability.TracingOptions{
+		Endpoint:    providerOptions.Observability.OTLPEndpoint,
+		Headers:     providerOptions.Observability.OTLPHeaders,
+		SampleRatio: providerOptions.Observability.TraceSampleRatio,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize tracing: %w", err)
+	}
+
+	registerer := prometheus.NewRegistry()
+	metrics := observability.NewMetrics(registerer)
+
+	if providerOptions.Observability.MetricsListenAddress != "" {
+		if err := observability.ValidateListenAddress(providerOptions.Observability.MetricsListenAddress); err != nil {
+			return nil, nil, fmt.Errorf("invalid --metrics-listen-address: %w", err)
+		}
+
+		go func() {
+			if err := observability.ServeMetrics(ctx, providerOptions.Observability.MetricsListenAddress, registerer); err != nil {
+				logger.Error("metrics server stopped", zap.Error(err))
+			}
+		}()
+	}
+
+	return metrics, shutdownTracing, nil
+}
@@ -0,0 +1,64 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	omniclient "github.com/siderolabs/omni/client/pkg/client"
+	"gopkg.in/yaml.v3"
+
+	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider/inventory"
+)
+
+// providerResourceNamespace is the COSI namespace machine resources are stored in on the Omni
+// side.
+const providerResourceNamespace = "infra-provider"
+
+func encodeJSON(w *os.File, machines []inventory.Machine) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(machines)
+}
+
+func encodeYAML(w *os.File, machines []inventory.Machine) error {
+	return yaml.NewEncoder(w).Encode(machines)
+}
+
+// connectOmniClient opens the Omni API connection used for both one-shot CLI queries and the
+// running provider daemon. Callers running as the daemon must hold on to the returned client
+// (see run() in main.go) and build the inventory.Lister from it, rather than calling this again,
+// so that a single daemon process only ever holds one Omni connection.
+func connectOmniClient(_ context.Context) (*omniclient.Client, error) {
+	if providerOptions.OmniAPIEndpoint == "" {
+		return nil, fmt.Errorf("--omni-api-endpoint (or OMNI_ENDPOINT) must be set")
+	}
+
+	client, err := omniclient.New(providerOptions.OmniAPIEndpoint, omniclient.WithInsecureSkipTLSVerify(providerOptions.InsecureSkipTLSVerify))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Omni API at %q: %w", providerOptions.OmniAPIEndpoint, err)
+	}
+
+	return client, nil
+}
+
+// newInventoryLister connects to Omni and returns a read-only inventory.Lister backed by the
+// same inventory.Client the running provider daemon uses. Only call this for a one-shot CLI
+// invocation (e.g. "list"/"describe"); the daemon instead builds its Lister from the Omni client
+// it already holds (see run() in main.go), so it doesn't open a second connection.
+func newInventoryLister(ctx context.Context) (inventory.Lister, error) {
+	client, err := connectOmniClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The client is intentionally left open for the lifetime of the one-shot CLI invocation; it is
+	// closed implicitly on process exit.
+	return inventory.NewClient(client.Omni().State(), providerResourceNamespace), nil
+}
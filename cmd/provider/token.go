@@ -0,0 +1,128 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"crypto/rand"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider/rbac"
+)
+
+// tokenSecretSuffix names the sibling file next to --rbac-policy-file that stores the HMAC secret
+// shared between "token issue" and the running provider's RBAC interceptors.
+const tokenSecretSuffix = ".token-secret"
+
+// loadTokenIssuer loads the token-signing secret from disk next to the configured RBAC policy
+// file, generating one on first use.
+func loadTokenIssuer() (*rbac.TokenIssuer, error) {
+	secretPath := providerOptions.RBAC.PolicyFile + tokenSecretSuffix
+
+	secret, err := os.ReadFile(secretPath)
+	if os.IsNotExist(err) {
+		secret = make([]byte, 32)
+		if _, err = rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("failed to generate RBAC token secret: %w", err)
+		}
+
+		if err = os.WriteFile(secretPath, secret, 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write RBAC token secret to %q: %w", secretPath, err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read RBAC token secret from %q: %w", secretPath, err)
+	}
+
+	return &rbac.TokenIssuer{Secret: secret}, nil
+}
+
+// setUpRBAC loads the RBAC policy file and token-signing secret and builds the gRPC interceptors
+// enforcing them, returning nil if --rbac-enabled is not set.
+func setUpRBAC(logger *zap.Logger) (*rbac.Interceptors, error) {
+	if !providerOptions.RBAC.Enabled {
+		return nil, nil
+	}
+
+	if providerOptions.RBAC.PolicyFile == "" {
+		return nil, fmt.Errorf("--rbac-policy-file must be set when --rbac-enabled is set")
+	}
+
+	policy, err := rbac.LoadPolicyFile(providerOptions.RBAC.PolicyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	issuer, err := loadTokenIssuer()
+	if err != nil {
+		return nil, err
+	}
+
+	rbac.DefaultTokenIssuer = issuer
+
+	return &rbac.Interceptors{Policy: policy, Enforce: providerOptions.RBAC.Enforce, Logger: logger}, nil
+}
+
+var tokenIssueOptions struct {
+	role      string
+	machineID string
+	ttl       time.Duration
+}
+
+func init() {
+	tokenCmd := &cobra.Command{
+		Use:   "token",
+		Short: "Manage RBAC bearer tokens for the provider's API",
+	}
+
+	tokenIssueCmd := &cobra.Command{
+		Use:   "issue",
+		Short: "Issue a bearer token bound to a role",
+		Args:  cobra.NoArgs,
+		RunE:  runTokenIssue,
+	}
+
+	tokenIssueCmd.Flags().StringVar(&tokenIssueOptions.role, "role", "", "The role to bind the token to. One of: agent|admin|read-only|power-only.")
+	tokenIssueCmd.Flags().StringVar(&tokenIssueOptions.machineID, "machine", "", "The machine ID to use as the token's subject. The machines the token may act on are set by its role's grants in the policy file, not by this flag.")
+	tokenIssueCmd.Flags().DurationVar(&tokenIssueOptions.ttl, "ttl", 24*time.Hour, "How long the issued token remains valid.")
+
+	if err := tokenIssueCmd.MarkFlagRequired("role"); err != nil {
+		panic(err)
+	}
+
+	tokenCmd.AddCommand(tokenIssueCmd)
+	rootCmd.AddCommand(tokenCmd)
+}
+
+func runTokenIssue(_ *cobra.Command, _ []string) error {
+	switch rbac.Role(tokenIssueOptions.role) {
+	case rbac.RoleAgent, rbac.RoleAdmin, rbac.RoleReadOnly, rbac.RolePowerOnly:
+	default:
+		return fmt.Errorf("unknown role %q", tokenIssueOptions.role)
+	}
+
+	if providerOptions.RBAC.PolicyFile == "" {
+		return fmt.Errorf("--rbac-policy-file must be set to issue tokens signed for this provider")
+	}
+
+	issuer, err := loadTokenIssuer()
+	if err != nil {
+		return err
+	}
+
+	subject := tokenIssueOptions.machineID
+	if subject == "" {
+		subject = fmt.Sprintf("%s-token", tokenIssueOptions.role)
+	}
+
+	token := issuer.Issue(subject, rbac.Role(tokenIssueOptions.role), tokenIssueOptions.ttl)
+
+	fmt.Println(token) //nolint:forbidigo
+
+	return nil
+}
@@ -0,0 +1,96 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider"
+	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider/bmc/virtualmedia"
+	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider/observability"
+)
+
+func init() {
+	rootCmd.Flags().StringVar(&providerOptions.VirtualMedia.ListenAddress, "virtual-media-listen-address",
+		provider.DefaultOptions.VirtualMedia.ListenAddress,
+		"The IP address and port to serve signed agent ISO URLs on for Redfish Virtual Media boot, e.g. \":8081\". "+
+			"Required for --boot-method=virtual-media or for any machine overriding its boot method to virtual-media.")
+	rootCmd.Flags().DurationVar(&providerOptions.VirtualMedia.URLTTL, "virtual-media-url-ttl", provider.DefaultOptions.VirtualMedia.URLTTL,
+		"How long a signed agent ISO URL handed to a BMC for Virtual Media boot remains valid.")
+}
+
+// setUpVirtualMedia starts the HTTP server serving signed agent ISO URLs for Redfish Virtual
+// Media boot, returning the Booter used to mount/eject media and the signer used to mint the
+// URLs handed to BMCs. It returns a nil Booter if --virtual-media-listen-address is not set.
+func setUpVirtualMedia(ctx context.Context, logger *zap.Logger, metrics *observability.Metrics) (*virtualmedia.Booter, virtualmedia.URLSigner, error) {
+	if providerOptions.VirtualMedia.ListenAddress == "" {
+		return nil, virtualmedia.URLSigner{}, nil
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, virtualmedia.URLSigner{}, fmt.Errorf("failed to generate virtual media URL signing secret: %w", err)
+	}
+
+	signer := virtualmedia.URLSigner{Secret: secret, TTL: providerOptions.VirtualMedia.URLTTL}
+	booter := virtualmedia.NewBooter()
+	booter.Metrics = metrics
+
+	mux := http.NewServeMux()
+	mux.Handle("/virtual-media/iso", newVirtualMediaHandler(logger, signer))
+
+	server := &http.Server{Addr: providerOptions.VirtualMedia.ListenAddress, Handler: mux}
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("virtual media ISO server stopped", zap.Error(err))
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		_ = server.Shutdown(context.Background()) //nolint:errcheck
+	}()
+
+	return booter, signer, nil
+}
+
+// newVirtualMediaHandler builds the handler serving agent ISOs to BMCs, forwarding to the image
+// factory the same way the iPXE handler does for network boot.
+func newVirtualMediaHandler(logger *zap.Logger, signer virtualmedia.URLSigner) virtualmedia.Handler {
+	isoFactory := virtualmedia.ISOFactory{BaseURL: providerOptions.ImageFactoryBaseURL}
+
+	return virtualmedia.Handler{
+		Signer: signer,
+		Logger: logger,
+		Fetch: func(w http.ResponseWriter, _ *http.Request, machineID string) error {
+			isoURL, err := isoFactory.AgentISOURL(providerOptions.AgentModeTalosVersion)
+			if err != nil {
+				return fmt.Errorf("failed to resolve agent ISO for machine %q: %w", machineID, err)
+			}
+
+			resp, err := http.Get(isoURL) //nolint:gosec,noctx
+			if err != nil {
+				return fmt.Errorf("failed to fetch agent ISO for machine %q: %w", machineID, err)
+			}
+			defer resp.Body.Close() //nolint:errcheck
+
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("image factory returned %s for agent ISO for machine %q", resp.Status, machineID)
+			}
+
+			_, err = io.Copy(w, resp.Body)
+
+			return err
+		},
+	}
+}
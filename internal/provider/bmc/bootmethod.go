@@ -0,0 +1,35 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package bmc
+
+import "fmt"
+
+// BootMethod selects how a machine is instructed to boot into agent/Talos mode.
+type BootMethod string
+
+// BootMethodLabel is the machine label used to override the provider's default --boot-method for
+// a specific machine.
+const BootMethodLabel = "bare-metal.sidero.dev/boot-method"
+
+// Supported boot methods.
+const (
+	// BootMethodIPXE boots the machine via an iPXE script served by the provider's iPXE handler.
+	BootMethodIPXE BootMethod = "ipxe"
+	// BootMethodPXE boots the machine via plain PXE, with the provider acting as a DHCP proxy.
+	BootMethodPXE BootMethod = "pxe"
+	// BootMethodVirtualMedia boots the machine from an agent ISO mounted over Redfish Virtual Media,
+	// for environments where DHCP/PXE cannot be intercepted.
+	BootMethodVirtualMedia BootMethod = "virtual-media"
+)
+
+// ParseBootMethod parses a --boot-method flag value into a BootMethod, rejecting unknown methods.
+func ParseBootMethod(s string) (BootMethod, error) {
+	switch m := BootMethod(s); m {
+	case BootMethodIPXE, BootMethodPXE, BootMethodVirtualMedia:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unknown boot method %q", s)
+	}
+}
@@ -0,0 +1,82 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package bmc
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// VendorCache persists the BMC vendor detected for each machine by DetectVendor, so that
+// subsequent power operations can skip re-probing the Redfish service root.
+//
+// It is safe for concurrent use. If it was created with an empty path, the cache is kept in
+// memory only and does not survive a provider restart.
+type VendorCache struct {
+	mu      sync.Mutex
+	path    string
+	vendors map[string]VendorID
+}
+
+// NewVendorCache creates a VendorCache, loading any previously persisted entries from path. If
+// path is empty, the cache starts empty and Set never writes it to disk.
+func NewVendorCache(path string) (*VendorCache, error) {
+	c := &VendorCache{path: path, vendors: map[string]VendorID{}}
+
+	if path == "" {
+		return c, nil
+	}
+
+	data, err := os.ReadFile(path)
+
+	switch {
+	case os.IsNotExist(err):
+		return c, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read BMC vendor cache %q: %w", path, err)
+	}
+
+	if err = json.Unmarshal(data, &c.vendors); err != nil {
+		return nil, fmt.Errorf("failed to parse BMC vendor cache %q: %w", path, err)
+	}
+
+	return c, nil
+}
+
+// Get returns the vendor previously detected or configured for machineID, if any.
+func (c *VendorCache) Get(machineID string) (VendorID, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	vendor, ok := c.vendors[machineID]
+
+	return vendor, ok
+}
+
+// Set records vendor as the resolved vendor for machineID, persisting it to disk if the cache was
+// created with a path.
+func (c *VendorCache) Set(machineID string, vendor VendorID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.vendors[machineID] = vendor
+
+	if c.path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(c.vendors)
+	if err != nil {
+		return fmt.Errorf("failed to marshal BMC vendor cache: %w", err)
+	}
+
+	if err = os.WriteFile(c.path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write BMC vendor cache %q: %w", c.path, err)
+	}
+
+	return nil
+}
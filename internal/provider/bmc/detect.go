@@ -0,0 +1,66 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package bmc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// redfishServiceRoot is the minimal subset of the Redfish "/redfish/v1/" service root document
+// used to fingerprint the BMC vendor.
+type redfishServiceRoot struct {
+	Chassis struct {
+		OdataID string `json:"@odata.id"`
+	} `json:"Chassis"`
+}
+
+// redfishChassis is the minimal subset of a Redfish Chassis resource used to fingerprint the BMC
+// vendor.
+type redfishChassis struct {
+	Manufacturer string `json:"Manufacturer"`
+}
+
+// DetectVendor probes transport's Redfish service root and chassis resource and returns the
+// VendorID that best matches the reported manufacturer, falling back to VendorGeneric when the
+// manufacturer is unrecognized.
+func DetectVendor(ctx context.Context, transport RedfishTransport) (VendorID, error) {
+	var root redfishServiceRoot
+
+	if err := transport.Get(ctx, "/redfish/v1/", &root); err != nil {
+		return "", fmt.Errorf("failed to fetch Redfish service root: %w", err)
+	}
+
+	if root.Chassis.OdataID == "" {
+		return VendorGeneric, nil
+	}
+
+	var chassis redfishChassis
+
+	if err := transport.Get(ctx, root.Chassis.OdataID, &chassis); err != nil {
+		return "", fmt.Errorf("failed to fetch Redfish chassis %q: %w", root.Chassis.OdataID, err)
+	}
+
+	return vendorFromManufacturer(chassis.Manufacturer), nil
+}
+
+// vendorFromManufacturer maps a Redfish Chassis "Manufacturer" string to a known VendorID.
+func vendorFromManufacturer(manufacturer string) VendorID {
+	m := strings.ToLower(manufacturer)
+
+	switch {
+	case strings.Contains(m, "dell"):
+		return VendorDell
+	case strings.Contains(m, "supermicro"):
+		return VendorSupermicro
+	case strings.Contains(m, "hpe") || strings.Contains(m, "hewlett packard"):
+		return VendorHPE
+	case strings.Contains(m, "lenovo"):
+		return VendorLenovo
+	default:
+		return VendorGeneric
+	}
+}
@@ -0,0 +1,110 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package bmc
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestVendorFromManufacturer(t *testing.T) {
+	tests := []struct {
+		manufacturer string
+		want         VendorID
+	}{
+		{"Dell Inc.", VendorDell},
+		{"DELL", VendorDell},
+		{"Super Micro Computer, Inc.", VendorGeneric},
+		{"Supermicro", VendorSupermicro},
+		{"HPE", VendorHPE},
+		{"Hewlett Packard Enterprise", VendorHPE},
+		{"Lenovo", VendorLenovo},
+		{"Quanta Cloud Technology", VendorGeneric},
+		{"", VendorGeneric},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.manufacturer, func(t *testing.T) {
+			if got := vendorFromManufacturer(tt.manufacturer); got != tt.want {
+				t.Errorf("vendorFromManufacturer(%q) = %q, want %q", tt.manufacturer, got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeTransport is a minimal RedfishTransport used to exercise DetectVendor without a real BMC.
+type fakeTransport struct {
+	serviceRoot redfishServiceRoot
+	chassis     map[string]redfishChassis
+	getErr      error
+}
+
+func (f fakeTransport) Get(_ context.Context, path string, out any) error {
+	if f.getErr != nil {
+		return f.getErr
+	}
+
+	switch v := out.(type) {
+	case *redfishServiceRoot:
+		*v = f.serviceRoot
+	case *redfishChassis:
+		chassis, ok := f.chassis[path]
+		if !ok {
+			return errors.New("no chassis at path")
+		}
+
+		*v = chassis
+	default:
+		return errors.New("unexpected out type")
+	}
+
+	return nil
+}
+
+func (fakeTransport) Patch(context.Context, string, any) error     { return nil }
+func (fakeTransport) Post(context.Context, string, any, any) error { return nil }
+
+func TestDetectVendor(t *testing.T) {
+	t.Run("detects known vendor from chassis", func(t *testing.T) {
+		transport := fakeTransport{
+			serviceRoot: redfishServiceRoot{Chassis: struct {
+				OdataID string `json:"@odata.id"`
+			}{OdataID: "/redfish/v1/Chassis/1"}},
+			chassis: map[string]redfishChassis{
+				"/redfish/v1/Chassis/1": {Manufacturer: "Dell Inc."},
+			},
+		}
+
+		vendor, err := DetectVendor(context.Background(), transport)
+		if err != nil {
+			t.Fatalf("DetectVendor() error = %v", err)
+		}
+
+		if vendor != VendorDell {
+			t.Errorf("DetectVendor() = %q, want %q", vendor, VendorDell)
+		}
+	})
+
+	t.Run("falls back to generic when no chassis is advertised", func(t *testing.T) {
+		vendor, err := DetectVendor(context.Background(), fakeTransport{})
+		if err != nil {
+			t.Fatalf("DetectVendor() error = %v", err)
+		}
+
+		if vendor != VendorGeneric {
+			t.Errorf("DetectVendor() = %q, want %q", vendor, VendorGeneric)
+		}
+	})
+
+	t.Run("propagates service root fetch errors", func(t *testing.T) {
+		wantErr := errors.New("boom")
+
+		_, err := DetectVendor(context.Background(), fakeTransport{getErr: wantErr})
+		if !errors.Is(err, wantErr) {
+			t.Errorf("DetectVendor() error = %v, want to wrap %v", err, wantErr)
+		}
+	})
+}
@@ -0,0 +1,72 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package dell implements bmc.VendorDriver for Dell iDRAC's Redfish implementation, overriding
+// the handful of operations where iDRAC deviates from the DMTF baseline.
+package dell
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider/bmc"
+	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider/bmc/drivers/generic"
+)
+
+func init() {
+	bmc.RegisterDriver(bmc.VendorDell, New)
+}
+
+// idracVirtualMediaInsertRequest mirrors generic's insert-media request, but iDRAC classifies
+// virtual media by the "WriteProtected" flag rather than inferring it, and rejects the request
+// if it is omitted.
+type idracVirtualMediaInsertRequest struct {
+	Image          string `json:"Image"`
+	Inserted       bool   `json:"Inserted"`
+	WriteProtected bool   `json:"WriteProtected"`
+}
+
+// Driver is the Dell iDRAC bmc.VendorDriver. It embeds the generic driver and overrides only the
+// operations where iDRAC's Redfish implementation deviates from the baseline.
+type Driver struct {
+	*generic.Driver
+}
+
+// New constructs a Dell iDRAC Driver.
+func New() bmc.VendorDriver {
+	return &Driver{Driver: generic.New().(*generic.Driver)} //nolint:forcetypeassert
+}
+
+// Vendor implements bmc.VendorDriver.
+func (d *Driver) Vendor() bmc.VendorID { return bmc.VendorDell }
+
+// SetOneTimeBoot implements bmc.VendorDriver.
+//
+// iDRAC rejects BootSourceOverrideMode on some firmware versions when the target is "Cd", so it
+// is only sent for non-virtual-media boot sources.
+func (d *Driver) SetOneTimeBoot(ctx context.Context, transport bmc.RedfishTransport, source bmc.BootSource) error {
+	d.Driver.SetBootSourceOverrideMode = source != bmc.BootSourceCD
+
+	return d.Driver.SetOneTimeBoot(ctx, transport, source)
+}
+
+// InsertVirtualMedia implements bmc.VendorDriver.
+//
+// Unlike the DMTF baseline, iDRAC requires WriteProtected to be set explicitly, and otherwise
+// classifies the inserted image as writable media, which can fail ISO boots on some firmware.
+func (d *Driver) InsertVirtualMedia(ctx context.Context, transport bmc.RedfishTransport, ref bmc.VirtualMediaRef, imageURL string) error {
+	path := fmt.Sprintf("%s/VirtualMedia/%s/Actions/VirtualMedia.InsertMedia", d.Driver.ManagerPath, ref)
+
+	req := idracVirtualMediaInsertRequest{
+		Image:          imageURL,
+		Inserted:       true,
+		WriteProtected: true,
+	}
+
+	if err := transport.Post(ctx, path, req, nil); err != nil {
+		return fmt.Errorf("failed to insert virtual media %q on iDRAC: %w", ref, err)
+	}
+
+	return nil
+}
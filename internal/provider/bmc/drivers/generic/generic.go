@@ -0,0 +1,140 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package generic implements bmc.VendorDriver against a plain DMTF-baseline Redfish
+// implementation, with no vendor-specific quirks.
+package generic
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider/bmc"
+)
+
+func init() {
+	bmc.RegisterDriver(bmc.VendorGeneric, New)
+}
+
+// systemResetRequest is the body of a Redfish ComputerSystem.Reset action.
+type systemResetRequest struct {
+	ResetType string `json:"ResetType"`
+}
+
+// boolOverrideEnabledRequest sets the boot source override on a Redfish ComputerSystem.
+type boolOverrideEnabledRequest struct {
+	Boot struct {
+		BootSourceOverrideEnabled string         `json:"BootSourceOverrideEnabled"`
+		BootSourceOverrideTarget  bmc.BootSource `json:"BootSourceOverrideTarget"`
+		BootSourceOverrideMode    string         `json:"BootSourceOverrideMode,omitempty"`
+	} `json:"Boot"`
+}
+
+// virtualMediaInsertRequest is the body of a Redfish VirtualMedia.InsertMedia action.
+type virtualMediaInsertRequest struct {
+	Image    string `json:"Image"`
+	Inserted bool   `json:"Inserted"`
+}
+
+// Driver is the generic, vendor-agnostic bmc.VendorDriver.
+type Driver struct {
+	// SystemPath is the Redfish path of the ComputerSystem to manage, e.g. "/redfish/v1/Systems/1".
+	SystemPath string
+	// ManagerPath is the Redfish path of the Manager that exposes VirtualMedia, e.g. "/redfish/v1/Managers/1".
+	ManagerPath string
+	// SetBootSourceOverrideMode controls whether BootSourceOverrideMode is sent on SetOneTimeBoot.
+	// Some Redfish implementations require this field to be left unset.
+	SetBootSourceOverrideMode bool
+}
+
+// New constructs a generic Driver. Paths default to the common single-system, single-manager
+// layout and can be overridden after construction once discovered.
+func New() bmc.VendorDriver {
+	return &Driver{
+		SystemPath:  "/redfish/v1/Systems/1",
+		ManagerPath: "/redfish/v1/Managers/1",
+	}
+}
+
+// Vendor implements bmc.VendorDriver.
+func (d *Driver) Vendor() bmc.VendorID { return bmc.VendorGeneric }
+
+// PowerOn implements bmc.VendorDriver.
+func (d *Driver) PowerOn(ctx context.Context, transport bmc.RedfishTransport) error {
+	return d.reset(ctx, transport, "On")
+}
+
+// PowerOff implements bmc.VendorDriver.
+func (d *Driver) PowerOff(ctx context.Context, transport bmc.RedfishTransport) error {
+	return d.reset(ctx, transport, "ForceOff")
+}
+
+// Reboot implements bmc.VendorDriver.
+func (d *Driver) Reboot(ctx context.Context, transport bmc.RedfishTransport) error {
+	return d.reset(ctx, transport, "ForceRestart")
+}
+
+func (d *Driver) reset(ctx context.Context, transport bmc.RedfishTransport, resetType string) error {
+	path := d.SystemPath + "/Actions/ComputerSystem.Reset"
+
+	if err := transport.Post(ctx, path, systemResetRequest{ResetType: resetType}, nil); err != nil {
+		return fmt.Errorf("failed to perform Redfish reset %q: %w", resetType, err)
+	}
+
+	return nil
+}
+
+// SetOneTimeBoot implements bmc.VendorDriver.
+func (d *Driver) SetOneTimeBoot(ctx context.Context, transport bmc.RedfishTransport, source bmc.BootSource) error {
+	var req boolOverrideEnabledRequest
+
+	req.Boot.BootSourceOverrideEnabled = "Once"
+	req.Boot.BootSourceOverrideTarget = source
+
+	if d.SetBootSourceOverrideMode {
+		req.Boot.BootSourceOverrideMode = "UEFI"
+	}
+
+	if err := transport.Patch(ctx, d.SystemPath, req); err != nil {
+		return fmt.Errorf("failed to set one-time boot source to %q: %w", source, err)
+	}
+
+	return nil
+}
+
+// InsertVirtualMedia implements bmc.VendorDriver.
+func (d *Driver) InsertVirtualMedia(ctx context.Context, transport bmc.RedfishTransport, ref bmc.VirtualMediaRef, imageURL string) error {
+	path := fmt.Sprintf("%s/VirtualMedia/%s/Actions/VirtualMedia.InsertMedia", d.ManagerPath, ref)
+
+	if err := transport.Post(ctx, path, virtualMediaInsertRequest{Image: imageURL, Inserted: true}, nil); err != nil {
+		return fmt.Errorf("failed to insert virtual media %q: %w", ref, err)
+	}
+
+	return nil
+}
+
+// EjectVirtualMedia implements bmc.VendorDriver.
+func (d *Driver) EjectVirtualMedia(ctx context.Context, transport bmc.RedfishTransport, ref bmc.VirtualMediaRef) error {
+	path := fmt.Sprintf("%s/VirtualMedia/%s/Actions/VirtualMedia.EjectMedia", d.ManagerPath, ref)
+
+	if err := transport.Post(ctx, path, struct{}{}, nil); err != nil {
+		return fmt.Errorf("failed to eject virtual media %q: %w", ref, err)
+	}
+
+	return nil
+}
+
+// Identify implements bmc.VendorDriver.
+func (d *Driver) Identify(ctx context.Context, transport bmc.RedfishTransport, on bool) error {
+	state := "Off"
+	if on {
+		state = "Blinking"
+	}
+
+	if err := transport.Patch(ctx, d.SystemPath, map[string]string{"IndicatorLED": state}); err != nil {
+		return fmt.Errorf("failed to set identify LED: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package bmc
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DriverFactory constructs a VendorDriver. Drivers register a factory under their VendorID via
+// RegisterDriver so that the registry can be built without every caller importing every vendor
+// package directly.
+type DriverFactory func() VendorDriver
+
+// Registry resolves a VendorID to a VendorDriver.
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[VendorID]DriverFactory
+}
+
+// NewRegistry creates an empty driver Registry.
+func NewRegistry() *Registry {
+	return &Registry{factories: map[VendorID]DriverFactory{}}
+}
+
+// DefaultRegistry is the Registry populated by the vendor driver packages' init functions.
+var DefaultRegistry = NewRegistry()
+
+// RegisterDriver registers factory under vendor in the DefaultRegistry.
+//
+// It is intended to be called from the init function of a vendor driver package, e.g.:
+//
+//	func init() { bmc.RegisterDriver(bmc.VendorDell, New) }
+func RegisterDriver(vendor VendorID, factory DriverFactory) {
+	DefaultRegistry.Register(vendor, factory)
+}
+
+// Register registers factory under vendor.
+func (r *Registry) Register(vendor VendorID, factory DriverFactory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.factories[vendor] = factory
+}
+
+// Get returns a new VendorDriver instance for vendor.
+func (r *Registry) Get(vendor VendorID) (VendorDriver, error) {
+	r.mu.RLock()
+	factory, ok := r.factories[vendor]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("no BMC vendor driver registered for %q", vendor)
+	}
+
+	return factory(), nil
+}
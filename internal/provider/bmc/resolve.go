@@ -0,0 +1,69 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package bmc
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider/observability"
+)
+
+// Resolve determines the VendorDriver to use for machineID, in order of precedence:
+//
+//  1. A per-machine override from --bmc-driver-overrides.
+//  2. A vendor previously resolved for machineID, recorded in cache.
+//  3. The process-global --bmc-driver flag, if it is not VendorAuto.
+//  4. Autodetection via DetectVendor, probing transport's Redfish service root.
+//
+// A vendor resolved via autodetection (step 4) is recorded in cache so that later calls for the
+// same machineID skip re-probing.
+func Resolve(
+	ctx context.Context,
+	machineID string,
+	overrides map[string]VendorID,
+	defaultDriver VendorID,
+	cache *VendorCache,
+	registry *Registry,
+	transport RedfishTransport,
+) (VendorDriver, VendorID, error) {
+	ctx, span := observability.Tracer().Start(ctx, "bmc.Resolve", trace.WithAttributes(
+		observability.MachineIDKey.String(machineID),
+	))
+	defer span.End()
+
+	if vendor, ok := overrides[machineID]; ok {
+		driver, err := registry.Get(vendor)
+
+		return driver, vendor, err
+	}
+
+	if vendor, ok := cache.Get(machineID); ok {
+		driver, err := registry.Get(vendor)
+
+		return driver, vendor, err
+	}
+
+	if defaultDriver != VendorAuto {
+		driver, err := registry.Get(defaultDriver)
+
+		return driver, defaultDriver, err
+	}
+
+	vendor, err := DetectVendor(ctx, transport)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to detect BMC vendor for machine %q: %w", machineID, err)
+	}
+
+	if err = cache.Set(machineID, vendor); err != nil {
+		return nil, "", fmt.Errorf("failed to persist detected BMC vendor for machine %q: %w", machineID, err)
+	}
+
+	driver, err := registry.Get(vendor)
+
+	return driver, vendor, err
+}
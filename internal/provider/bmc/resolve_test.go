@@ -0,0 +1,131 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package bmc
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+type fakeDriver struct{ vendor VendorID }
+
+func (d fakeDriver) Vendor() VendorID                               { return d.vendor }
+func (fakeDriver) PowerOn(context.Context, RedfishTransport) error  { return nil }
+func (fakeDriver) PowerOff(context.Context, RedfishTransport) error { return nil }
+func (fakeDriver) Reboot(context.Context, RedfishTransport) error   { return nil }
+func (fakeDriver) SetOneTimeBoot(context.Context, RedfishTransport, BootSource) error {
+	return nil
+}
+func (fakeDriver) InsertVirtualMedia(context.Context, RedfishTransport, VirtualMediaRef, string) error {
+	return nil
+}
+func (fakeDriver) EjectVirtualMedia(context.Context, RedfishTransport, VirtualMediaRef) error {
+	return nil
+}
+func (fakeDriver) Identify(context.Context, RedfishTransport, bool) error { return nil }
+
+func newTestRegistry() *Registry {
+	registry := NewRegistry()
+	for _, vendor := range []VendorID{VendorGeneric, VendorDell, VendorSupermicro} {
+		registry.Register(vendor, func() VendorDriver { return fakeDriver{vendor: vendor} })
+	}
+
+	return registry
+}
+
+func TestResolve(t *testing.T) {
+	registry := newTestRegistry()
+	transport := fakeTransport{
+		serviceRoot: redfishServiceRoot{Chassis: struct {
+			OdataID string `json:"@odata.id"`
+		}{OdataID: "/redfish/v1/Chassis/1"}},
+		chassis: map[string]redfishChassis{
+			"/redfish/v1/Chassis/1": {Manufacturer: "Dell Inc."},
+		},
+	}
+
+	t.Run("per-machine override wins", func(t *testing.T) {
+		cache, err := NewVendorCache("")
+		if err != nil {
+			t.Fatalf("NewVendorCache() error = %v", err)
+		}
+
+		_, vendor, err := Resolve(context.Background(), "machine-1",
+			map[string]VendorID{"machine-1": VendorSupermicro}, VendorAuto, cache, registry, transport)
+		if err != nil {
+			t.Fatalf("Resolve() error = %v", err)
+		}
+
+		if vendor != VendorSupermicro {
+			t.Errorf("Resolve() = %q, want %q", vendor, VendorSupermicro)
+		}
+	})
+
+	t.Run("cached vendor skips re-probing", func(t *testing.T) {
+		cache, err := NewVendorCache("")
+		if err != nil {
+			t.Fatalf("NewVendorCache() error = %v", err)
+		}
+
+		if err = cache.Set("machine-2", VendorSupermicro); err != nil {
+			t.Fatalf("cache.Set() error = %v", err)
+		}
+
+		_, vendor, err := Resolve(context.Background(), "machine-2", nil, VendorAuto, cache, registry,
+			fakeTransport{getErr: errors.New("should not be called")})
+		if err != nil {
+			t.Fatalf("Resolve() error = %v, want cache hit to avoid probing", err)
+		}
+
+		if vendor != VendorSupermicro {
+			t.Errorf("Resolve() = %q, want %q", vendor, VendorSupermicro)
+		}
+	})
+
+	t.Run("default driver used when not auto", func(t *testing.T) {
+		cache, err := NewVendorCache("")
+		if err != nil {
+			t.Fatalf("NewVendorCache() error = %v", err)
+		}
+
+		_, vendor, err := Resolve(context.Background(), "machine-3", nil, VendorGeneric, cache, registry, transport)
+		if err != nil {
+			t.Fatalf("Resolve() error = %v", err)
+		}
+
+		if vendor != VendorGeneric {
+			t.Errorf("Resolve() = %q, want %q", vendor, VendorGeneric)
+		}
+	})
+
+	t.Run("autodetects and persists the result", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "vendor-cache.json")
+
+		cache, err := NewVendorCache(path)
+		if err != nil {
+			t.Fatalf("NewVendorCache() error = %v", err)
+		}
+
+		_, vendor, err := Resolve(context.Background(), "machine-4", nil, VendorAuto, cache, registry, transport)
+		if err != nil {
+			t.Fatalf("Resolve() error = %v", err)
+		}
+
+		if vendor != VendorDell {
+			t.Errorf("Resolve() = %q, want %q", vendor, VendorDell)
+		}
+
+		reloaded, err := NewVendorCache(path)
+		if err != nil {
+			t.Fatalf("NewVendorCache() reload error = %v", err)
+		}
+
+		if got, ok := reloaded.Get("machine-4"); !ok || got != VendorDell {
+			t.Errorf("reloaded cache.Get(%q) = (%q, %v), want (%q, true)", "machine-4", got, ok, VendorDell)
+		}
+	})
+}
@@ -0,0 +1,80 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package bmc provides vendor-agnostic abstractions over the various ways the provider
+// can talk to a machine's baseboard management controller.
+package bmc
+
+import (
+	"context"
+	"fmt"
+)
+
+// VendorID identifies a BMC vendor whose Redfish implementation needs vendor-specific handling.
+type VendorID string
+
+// Known vendor IDs.
+const (
+	VendorAuto       VendorID = "auto"
+	VendorGeneric    VendorID = "generic"
+	VendorDell       VendorID = "dell"
+	VendorSupermicro VendorID = "supermicro"
+	VendorHPE        VendorID = "hpe"
+	VendorLenovo     VendorID = "lenovo"
+)
+
+// RedfishTransport is the subset of the Redfish client used by vendor drivers to talk to a BMC.
+//
+// It is implemented by the provider's Redfish client; it is declared here so that drivers do not
+// need to depend on the concrete client type.
+type RedfishTransport interface {
+	Get(ctx context.Context, path string, out any) error
+	Patch(ctx context.Context, path string, body any) error
+	Post(ctx context.Context, path string, body any, out any) error
+}
+
+// VirtualMediaRef identifies a virtual media slot exposed by the BMC, e.g. "Cd" or "DVD".
+type VirtualMediaRef string
+
+// VendorDriver implements the vendor-specific parts of BMC power and boot management over Redfish.
+//
+// A generic, DMTF-baseline implementation is provided by the generic driver; vendor-specific
+// drivers embed it and override only the methods whose behavior deviates from the baseline.
+type VendorDriver interface {
+	// Vendor returns the vendor ID this driver implements.
+	Vendor() VendorID
+
+	PowerOn(ctx context.Context, transport RedfishTransport) error
+	PowerOff(ctx context.Context, transport RedfishTransport) error
+	Reboot(ctx context.Context, transport RedfishTransport) error
+
+	// SetOneTimeBoot configures the system to boot from the given source exactly once.
+	SetOneTimeBoot(ctx context.Context, transport RedfishTransport, source BootSource) error
+
+	InsertVirtualMedia(ctx context.Context, transport RedfishTransport, ref VirtualMediaRef, imageURL string) error
+	EjectVirtualMedia(ctx context.Context, transport RedfishTransport, ref VirtualMediaRef) error
+
+	// Identify toggles the chassis identify LED, used to visually locate a machine in a rack.
+	Identify(ctx context.Context, transport RedfishTransport, on bool) error
+}
+
+// BootSource is a Redfish boot source override target, e.g. "Cd", "Pxe", "Hdd".
+type BootSource string
+
+// Boot sources supported by SetOneTimeBoot.
+const (
+	BootSourcePXE BootSource = "Pxe"
+	BootSourceCD  BootSource = "Cd"
+	BootSourceHDD BootSource = "Hdd"
+)
+
+// ParseVendorID parses a --bmc-driver flag value into a VendorID, rejecting unknown vendors.
+func ParseVendorID(s string) (VendorID, error) {
+	switch v := VendorID(s); v {
+	case VendorAuto, VendorGeneric, VendorDell, VendorSupermicro, VendorHPE, VendorLenovo:
+		return v, nil
+	default:
+		return "", fmt.Errorf("unknown BMC vendor driver %q", s)
+	}
+}
@@ -0,0 +1,125 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package virtualmedia
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider/bmc"
+	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider/observability"
+)
+
+// mediaRef is the Redfish virtual media slot the agent ISO is mounted on.
+const mediaRef bmc.VirtualMediaRef = "Cd"
+
+// Booter boots a machine into agent/Talos mode by mounting the agent ISO as Redfish Virtual
+// Media, rather than relying on iPXE/PXE.
+//
+// The media is kept mounted until EjectAfterCheckIn is called for the machine, so that the
+// machine can retry the boot (e.g. after a BIOS POST failure) without the provider racing to
+// eject the media too early.
+type Booter struct {
+	// Metrics records power-operation durations. It is optional; a nil Metrics disables recording.
+	Metrics *observability.Metrics
+
+	mu      sync.Mutex
+	pending map[string]pendingEject
+}
+
+type pendingEject struct {
+	driver    bmc.VendorDriver
+	transport bmc.RedfishTransport
+}
+
+// NewBooter creates a Booter. Use the Metrics field to record power-operation metrics.
+func NewBooter() *Booter {
+	return &Booter{pending: map[string]pendingEject{}}
+}
+
+// Boot mounts imageURL as virtual media on the machine, sets a one-time boot to CD, and power
+// cycles the machine. The media is left mounted until EjectAfterCheckIn is called with machineID.
+func (b *Booter) Boot(ctx context.Context, machineID string, driver bmc.VendorDriver, transport bmc.RedfishTransport, imageURL string) error {
+	ctx, span := observability.Tracer().Start(ctx, "virtualmedia.Boot", trace.WithAttributes(
+		observability.MachineAttributes(machineID, string(driver.Vendor()), string(bmc.BootMethodVirtualMedia))...,
+	))
+	defer span.End()
+
+	if err := b.timeOp(ctx, "insert_virtual_media", driver.Vendor(), func() error {
+		return driver.InsertVirtualMedia(ctx, transport, mediaRef, imageURL)
+	}); err != nil {
+		return fmt.Errorf("failed to insert virtual media for machine %q: %w", machineID, err)
+	}
+
+	if err := b.timeOp(ctx, "set_one_time_boot", driver.Vendor(), func() error {
+		return driver.SetOneTimeBoot(ctx, transport, bmc.BootSourceCD)
+	}); err != nil {
+		return fmt.Errorf("failed to set one-time boot to CD for machine %q: %w", machineID, err)
+	}
+
+	if err := b.timeOp(ctx, "reboot", driver.Vendor(), func() error {
+		return driver.Reboot(ctx, transport)
+	}); err != nil {
+		return fmt.Errorf("failed to power cycle machine %q for virtual media boot: %w", machineID, err)
+	}
+
+	b.mu.Lock()
+	b.pending[machineID] = pendingEject{driver: driver, transport: transport}
+	b.mu.Unlock()
+
+	return nil
+}
+
+// EjectAfterCheckIn ejects the virtual media previously mounted for machineID by Boot. It is a
+// no-op if no media is pending eject for machineID, so it is safe to call on every agent
+// check-in without tracking boot method separately.
+//
+// The caller is the provider's agent check-in handler, which invokes this once the agent that was
+// mounted via Boot has reported in; that handler is part of the provider's gRPC API server and is
+// outside this package.
+func (b *Booter) EjectAfterCheckIn(ctx context.Context, machineID string) error {
+	b.mu.Lock()
+	pending, ok := b.pending[machineID]
+	delete(b.pending, machineID)
+	b.mu.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	ctx, span := observability.Tracer().Start(ctx, "virtualmedia.EjectAfterCheckIn", trace.WithAttributes(
+		observability.MachineAttributes(machineID, string(pending.driver.Vendor()), string(bmc.BootMethodVirtualMedia))...,
+	))
+	defer span.End()
+
+	if err := b.timeOp(ctx, "eject_virtual_media", pending.driver.Vendor(), func() error {
+		return pending.driver.EjectVirtualMedia(ctx, pending.transport, mediaRef)
+	}); err != nil {
+		return fmt.Errorf("failed to eject virtual media for machine %q: %w", machineID, err)
+	}
+
+	return nil
+}
+
+// timeOp runs op, recording its duration and outcome to provider_power_op_duration_seconds.
+func (b *Booter) timeOp(_ context.Context, op string, vendor bmc.VendorID, fn func() error) error {
+	start := time.Now()
+	err := fn()
+
+	if b.Metrics != nil {
+		result := "success"
+		if err != nil {
+			result = "error"
+		}
+
+		b.Metrics.PowerOpDuration.WithLabelValues(op, string(vendor), result).Observe(time.Since(start).Seconds())
+	}
+
+	return err
+}
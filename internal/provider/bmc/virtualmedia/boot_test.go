@@ -0,0 +1,116 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package virtualmedia
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider/bmc"
+)
+
+type fakeDriver struct {
+	vendor bmc.VendorID
+
+	insertErr error
+	bootErr   error
+	rebootErr error
+	ejectErr  error
+
+	inserted bool
+	ejected  bool
+}
+
+func (d *fakeDriver) Vendor() bmc.VendorID { return d.vendor }
+
+func (d *fakeDriver) PowerOn(context.Context, bmc.RedfishTransport) error  { return nil }
+func (d *fakeDriver) PowerOff(context.Context, bmc.RedfishTransport) error { return nil }
+func (d *fakeDriver) Reboot(_ context.Context, _ bmc.RedfishTransport) error {
+	return d.rebootErr
+}
+
+func (d *fakeDriver) SetOneTimeBoot(_ context.Context, _ bmc.RedfishTransport, _ bmc.BootSource) error {
+	return d.bootErr
+}
+
+func (d *fakeDriver) InsertVirtualMedia(_ context.Context, _ bmc.RedfishTransport, _ bmc.VirtualMediaRef, _ string) error {
+	if d.insertErr != nil {
+		return d.insertErr
+	}
+
+	d.inserted = true
+
+	return nil
+}
+
+func (d *fakeDriver) EjectVirtualMedia(_ context.Context, _ bmc.RedfishTransport, _ bmc.VirtualMediaRef) error {
+	if d.ejectErr != nil {
+		return d.ejectErr
+	}
+
+	d.ejected = true
+
+	return nil
+}
+
+func (d *fakeDriver) Identify(context.Context, bmc.RedfishTransport, bool) error { return nil }
+
+type fakeTransport struct{}
+
+func (fakeTransport) Get(context.Context, string, any) error       { return nil }
+func (fakeTransport) Patch(context.Context, string, any) error     { return nil }
+func (fakeTransport) Post(context.Context, string, any, any) error { return nil }
+
+func TestBooterBootThenEjectAfterCheckIn(t *testing.T) {
+	booter := NewBooter()
+	driver := &fakeDriver{vendor: bmc.VendorGeneric}
+
+	if err := booter.Boot(context.Background(), "machine-1", driver, fakeTransport{}, "http://example.com/agent.iso"); err != nil {
+		t.Fatalf("Boot() = %v, want nil", err)
+	}
+
+	if !driver.inserted {
+		t.Error("Boot() did not insert virtual media")
+	}
+
+	if driver.ejected {
+		t.Error("Boot() ejected virtual media before EjectAfterCheckIn was called")
+	}
+
+	if err := booter.EjectAfterCheckIn(context.Background(), "machine-1"); err != nil {
+		t.Fatalf("EjectAfterCheckIn() = %v, want nil", err)
+	}
+
+	if !driver.ejected {
+		t.Error("EjectAfterCheckIn() did not eject virtual media")
+	}
+}
+
+func TestBooterEjectAfterCheckInWithNothingPendingIsANoOp(t *testing.T) {
+	booter := NewBooter()
+
+	if err := booter.EjectAfterCheckIn(context.Background(), "machine-without-a-pending-boot"); err != nil {
+		t.Errorf("EjectAfterCheckIn() for a machine with nothing pending = %v, want nil", err)
+	}
+}
+
+func TestBooterBootPropagatesDriverErrors(t *testing.T) {
+	booter := NewBooter()
+	driver := &fakeDriver{vendor: bmc.VendorGeneric, insertErr: errors.New("insert failed")}
+
+	if err := booter.Boot(context.Background(), "machine-1", driver, fakeTransport{}, "http://example.com/agent.iso"); err == nil {
+		t.Error("Boot() with a failing driver = nil, want error")
+	}
+
+	// A failed Boot must not leave a pending eject behind.
+	if err := booter.EjectAfterCheckIn(context.Background(), "machine-1"); err != nil {
+		t.Errorf("EjectAfterCheckIn() after a failed Boot = %v, want nil (no-op)", err)
+	}
+
+	if driver.ejected {
+		t.Error("EjectAfterCheckIn() ejected media for a Boot that never completed")
+	}
+}
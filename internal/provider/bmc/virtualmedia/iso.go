@@ -0,0 +1,35 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package virtualmedia implements booting machines into agent/Talos mode via Redfish Virtual
+// Media, as an alternative to iPXE/PXE for environments where DHCP/PXE cannot be intercepted
+// (e.g. air-gapped racks managed over iDRAC/iLO).
+package virtualmedia
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// ISOFactory resolves the image factory URL of the agent ISO for a given Talos version, mirroring
+// how the iPXE handler forwards boot requests to the image factory.
+type ISOFactory struct {
+	// BaseURL is the base URL of the image factory, e.g. providerOptions.ImageFactoryBaseURL.
+	BaseURL string
+}
+
+// AgentISOURL returns the image factory URL of the agent-mode ISO for talosVersion.
+func (f ISOFactory) AgentISOURL(talosVersion string) (string, error) {
+	base, err := url.Parse(f.BaseURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse image factory base URL: %w", err)
+	}
+
+	base.Path, err = url.JoinPath(base.Path, "image", "metal-agent", talosVersion, "metal-amd64.iso")
+	if err != nil {
+		return "", fmt.Errorf("failed to build image factory ISO path: %w", err)
+	}
+
+	return base.String(), nil
+}
@@ -0,0 +1,46 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package virtualmedia
+
+import (
+	"net/http"
+
+	"go.uber.org/zap"
+)
+
+// Handler serves agent ISOs under short-lived signed URLs generated by URLSigner, so that
+// Redfish VirtualMedia.InsertMedia can reference an Image the BMC can fetch directly over HTTP.
+type Handler struct {
+	Signer URLSigner
+	Logger *zap.Logger
+
+	// Fetch resolves the image factory ISO for a machine, by machine ID, and streams it to w.
+	// It is provided by the caller, closing over the Talos version and ISOFactory to use.
+	Fetch func(w http.ResponseWriter, r *http.Request, machineID string) error
+}
+
+// ServeHTTP implements http.Handler.
+func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	machineID := q.Get("machine_id")
+	if machineID == "" {
+		http.Error(w, "missing machine_id", http.StatusBadRequest)
+
+		return
+	}
+
+	if err := h.Signer.Verify(machineID, q.Get("expires"), q.Get("sig")); err != nil {
+		h.Logger.Warn("rejected virtual media ISO request", zap.String("machine_id", machineID), zap.Error(err))
+		http.Error(w, "invalid or expired URL", http.StatusForbidden)
+
+		return
+	}
+
+	if err := h.Fetch(w, r, machineID); err != nil {
+		h.Logger.Error("failed to serve virtual media ISO", zap.String("machine_id", machineID), zap.Error(err))
+		http.Error(w, "failed to serve ISO", http.StatusBadGateway)
+	}
+}
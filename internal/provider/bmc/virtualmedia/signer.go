@@ -0,0 +1,69 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package virtualmedia
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// URLSigner signs and verifies short-lived URLs under which the provider's HTTP server exposes
+// agent ISOs to BMCs. The BMC is given only the signed URL, never direct access to the image
+// factory or any credentials.
+type URLSigner struct {
+	// Secret is used to HMAC-sign URLs. It should be a random value generated at provider startup.
+	Secret []byte
+	// TTL is how long a signed URL remains valid after it is issued.
+	TTL time.Duration
+}
+
+// Sign returns machineID and expiry query parameters, plus a signature, appended to rawURL.
+func (s URLSigner) Sign(rawURL, machineID string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse URL to sign: %w", err)
+	}
+
+	expiry := time.Now().Add(s.TTL).Unix()
+
+	q := u.Query()
+	q.Set("machine_id", machineID)
+	q.Set("expires", strconv.FormatInt(expiry, 10))
+	q.Set("sig", s.sign(machineID, expiry))
+	u.RawQuery = q.Encode()
+
+	return u.String(), nil
+}
+
+// Verify checks that a request for machineID with the given expires/sig query parameters is
+// valid and not expired.
+func (s URLSigner) Verify(machineID, expires, sig string) error {
+	expiry, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid expires parameter: %w", err)
+	}
+
+	if time.Now().Unix() > expiry {
+		return fmt.Errorf("signed virtual media URL for machine %q expired", machineID)
+	}
+
+	if !hmac.Equal([]byte(sig), []byte(s.sign(machineID, expiry))) {
+		return fmt.Errorf("invalid signature for machine %q", machineID)
+	}
+
+	return nil
+}
+
+func (s URLSigner) sign(machineID string, expiry int64) string {
+	mac := hmac.New(sha256.New, s.Secret)
+	fmt.Fprintf(mac, "%s:%d", machineID, expiry)
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,110 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package virtualmedia
+
+import (
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestURLSignerSignVerify(t *testing.T) {
+	signer := URLSigner{Secret: []byte("test-secret"), TTL: time.Hour}
+
+	signed, err := signer.Sign("https://factory.example/image/metal-agent/v1.7.0/metal-amd64.iso", "machine-1")
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	u, err := url.Parse(signed)
+	if err != nil {
+		t.Fatalf("failed to parse signed URL: %v", err)
+	}
+
+	q := u.Query()
+
+	if err = signer.Verify(q.Get("machine_id"), q.Get("expires"), q.Get("sig")); err != nil {
+		t.Errorf("Verify() error = %v, want nil", err)
+	}
+}
+
+func TestURLSignerVerifyRejects(t *testing.T) {
+	signer := URLSigner{Secret: []byte("test-secret"), TTL: time.Hour}
+
+	signed, err := signer.Sign("https://factory.example/iso", "machine-1")
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	u, err := url.Parse(signed)
+	if err != nil {
+		t.Fatalf("failed to parse signed URL: %v", err)
+	}
+
+	q := u.Query()
+	validExpires, validSig := q.Get("expires"), q.Get("sig")
+
+	tests := []struct {
+		name      string
+		machineID string
+		expires   string
+		sig       string
+	}{
+		{"wrong machine id", "machine-2", validExpires, validSig},
+		{"tampered expiry", "machine-1", strconv.FormatInt(time.Now().Add(24*time.Hour).Unix(), 10), validSig},
+		{"garbage signature", "machine-1", validExpires, "not-a-real-signature"},
+		{"malformed expiry", "machine-1", "not-a-number", validSig},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := signer.Verify(tt.machineID, tt.expires, tt.sig); err == nil {
+				t.Errorf("Verify(%q, %q, %q) = nil, want error", tt.machineID, tt.expires, tt.sig)
+			}
+		})
+	}
+
+	t.Run("expired with otherwise valid signature", func(t *testing.T) {
+		expired := URLSigner{Secret: []byte("test-secret"), TTL: -time.Minute}
+
+		signed, err := expired.Sign("https://factory.example/iso", "machine-1")
+		if err != nil {
+			t.Fatalf("Sign() error = %v", err)
+		}
+
+		u, err := url.Parse(signed)
+		if err != nil {
+			t.Fatalf("failed to parse signed URL: %v", err)
+		}
+
+		q := u.Query()
+
+		if err := signer.Verify(q.Get("machine_id"), q.Get("expires"), q.Get("sig")); err == nil {
+			t.Error("Verify() for an expired URL = nil, want error")
+		}
+	})
+}
+
+func TestURLSignerDifferentSecretsRejectEachOther(t *testing.T) {
+	a := URLSigner{Secret: []byte("secret-a"), TTL: time.Hour}
+	b := URLSigner{Secret: []byte("secret-b"), TTL: time.Hour}
+
+	signed, err := a.Sign("https://factory.example/iso", "machine-1")
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	u, err := url.Parse(signed)
+	if err != nil {
+		t.Fatalf("failed to parse signed URL: %v", err)
+	}
+
+	q := u.Query()
+
+	if err := b.Verify(q.Get("machine_id"), q.Get("expires"), q.Get("sig")); err == nil {
+		t.Error("Verify() with a different secret = nil, want error")
+	}
+}
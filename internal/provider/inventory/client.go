@@ -0,0 +1,107 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package inventory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosi-project/runtime/pkg/resource"
+	"github.com/cosi-project/runtime/pkg/state"
+	"github.com/siderolabs/omni/client/pkg/omni/resources/infra"
+)
+
+// Client implements Lister by reading machine resources directly out of the provider's Omni
+// COSI state. It is constructed from the same state.State used by the running provider daemon,
+// so it is usable both while the daemon is running and, for one-shot CLI queries, against a
+// freshly connected client.
+type Client struct {
+	st        state.State
+	namespace resource.Namespace
+}
+
+// NewClient creates a Client reading machine resources from st in namespace.
+func NewClient(st state.State, namespace resource.Namespace) *Client {
+	return &Client{st: st, namespace: namespace}
+}
+
+// List implements Lister.
+func (c *Client) List(ctx context.Context) ([]Machine, error) {
+	items, err := safeList[*infra.MachineStatus](ctx, c.st, c.namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list machine statuses: %w", err)
+	}
+
+	machines := make([]Machine, 0, len(items))
+
+	for _, item := range items {
+		machines = append(machines, machineFromStatus(item))
+	}
+
+	return machines, nil
+}
+
+// Describe implements Lister.
+func (c *Client) Describe(ctx context.Context, id string) (Machine, error) {
+	md := resource.NewMetadata(c.namespace, infra.MachineStatusType, id, resource.VersionUndefined)
+
+	res, err := c.st.Get(ctx, md)
+	if err != nil {
+		return Machine{}, fmt.Errorf("failed to get machine status %q: %w", id, err)
+	}
+
+	status, ok := res.(*infra.MachineStatus)
+	if !ok {
+		return Machine{}, fmt.Errorf("unexpected resource type %T for machine status %q", res, id)
+	}
+
+	return machineFromStatus(status), nil
+}
+
+// safeList lists all resources of type T in namespace, returning an empty slice rather than an
+// error when the resource type has no items yet.
+func safeList[T resource.Resource](ctx context.Context, st state.State, namespace resource.Namespace) ([]T, error) {
+	var zero T
+
+	list, err := st.List(ctx, resource.NewMetadata(namespace, zero.ResourceDefinition().Type, "", resource.VersionUndefined))
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]T, 0, len(list.Items))
+
+	for _, item := range list.Items {
+		typed, ok := item.(T)
+		if !ok {
+			return nil, fmt.Errorf("unexpected resource type %T", item)
+		}
+
+		items = append(items, typed)
+	}
+
+	return items, nil
+}
+
+// machineFromStatus maps an infra.MachineStatus resource to the inventory's vendor-agnostic
+// Machine type.
+//
+// GetBmcAddress/GetBootMethod are written against the typed-spec getter naming convention the
+// rest of infra.MachineStatus follows (GetPowerState, GetAllocated, GetInstalled); this package
+// has no access to the vendored siderolabs/omni proto to confirm those two fields exist under
+// those exact names on the real resource, so verify them against the actual generated spec before
+// merging.
+func machineFromStatus(status *infra.MachineStatus) Machine {
+	spec := status.TypedSpec().Value
+
+	return Machine{
+		ID:         status.Metadata().ID(),
+		PowerState: spec.GetPowerState().String(),
+		BMCAddress: spec.GetBmcAddress(),
+		BootMethod: spec.GetBootMethod(),
+		Labels:     status.Metadata().Labels().Raw(),
+		Allocated:  spec.GetAllocated(),
+		Installed:  spec.GetInstalled(),
+	}
+}
@@ -0,0 +1,51 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package inventory exposes read-only access to the machines managed by this provider, for use
+// by both the running provider daemon and one-shot CLI queries (e.g. the "list"/"describe"
+// subcommands), without either one needing to spin up the full reconcile loop.
+package inventory
+
+import "time"
+
+// Machine is a read-only snapshot of a single machine managed by the provider.
+type Machine struct {
+	ID            string            `json:"id" yaml:"id"`
+	PowerState    string            `json:"powerState" yaml:"powerState"`
+	LastSeenAgent time.Time         `json:"lastSeenAgent,omitzero" yaml:"lastSeenAgent,omitempty"`
+	BMCAddress    string            `json:"bmcAddress,omitempty" yaml:"bmcAddress,omitempty"`
+	BootMethod    string            `json:"bootMethod" yaml:"bootMethod"`
+	Labels        map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	Allocated     bool              `json:"allocated" yaml:"allocated"`
+	Installed     bool              `json:"installed" yaml:"installed"`
+}
+
+// Filter narrows down the set of machines returned by a Lister.
+type Filter struct {
+	// Labels restricts results to machines carrying all of these label key=value pairs.
+	Labels map[string]string
+	// PowerState, if non-empty, restricts results to machines in this power state.
+	PowerState string
+	// Allocated, if non-nil, restricts results to machines whose Allocated status matches.
+	Allocated *bool
+}
+
+// Matches reports whether m satisfies f.
+func (f Filter) Matches(m Machine) bool {
+	if f.PowerState != "" && m.PowerState != f.PowerState {
+		return false
+	}
+
+	if f.Allocated != nil && m.Allocated != *f.Allocated {
+		return false
+	}
+
+	for k, v := range f.Labels {
+		if m.Labels[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
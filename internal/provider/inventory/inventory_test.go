@@ -0,0 +1,75 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package inventory
+
+import "testing"
+
+func TestFilterMatches(t *testing.T) {
+	allocated := true
+
+	tests := []struct {
+		name   string
+		filter Filter
+		m      Machine
+		want   bool
+	}{
+		{
+			name:   "no filter matches everything",
+			filter: Filter{},
+			m:      Machine{ID: "m1"},
+			want:   true,
+		},
+		{
+			name:   "power state mismatch",
+			filter: Filter{PowerState: "on"},
+			m:      Machine{ID: "m1", PowerState: "off"},
+			want:   false,
+		},
+		{
+			name:   "power state match",
+			filter: Filter{PowerState: "on"},
+			m:      Machine{ID: "m1", PowerState: "on"},
+			want:   true,
+		},
+		{
+			name:   "allocated mismatch",
+			filter: Filter{Allocated: &allocated},
+			m:      Machine{ID: "m1", Allocated: false},
+			want:   false,
+		},
+		{
+			name:   "allocated match",
+			filter: Filter{Allocated: &allocated},
+			m:      Machine{ID: "m1", Allocated: true},
+			want:   true,
+		},
+		{
+			name:   "missing label",
+			filter: Filter{Labels: map[string]string{"rack": "a"}},
+			m:      Machine{ID: "m1", Labels: map[string]string{"row": "1"}},
+			want:   false,
+		},
+		{
+			name:   "mismatched label value",
+			filter: Filter{Labels: map[string]string{"rack": "a"}},
+			m:      Machine{ID: "m1", Labels: map[string]string{"rack": "b"}},
+			want:   false,
+		},
+		{
+			name:   "all labels match",
+			filter: Filter{Labels: map[string]string{"rack": "a", "row": "1"}},
+			m:      Machine{ID: "m1", Labels: map[string]string{"rack": "a", "row": "1", "extra": "ignored"}},
+			want:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(tt.m); got != tt.want {
+				t.Errorf("Filter.Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
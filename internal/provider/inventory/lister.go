@@ -0,0 +1,41 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// Lister reads the current set of machines managed by this provider. It is implemented by the
+// provider's Omni client, and is the interface shared between the running daemon and one-shot
+// CLI queries.
+type Lister interface {
+	// List returns all machines known to the provider, unfiltered.
+	List(ctx context.Context) ([]Machine, error)
+	// Describe returns a single machine by ID.
+	Describe(ctx context.Context, id string) (Machine, error)
+}
+
+// List returns the machines returned by lister that match filter, sorted by ID.
+func List(ctx context.Context, lister Lister, filter Filter) ([]Machine, error) {
+	machines, err := lister.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list machines: %w", err)
+	}
+
+	filtered := machines[:0]
+
+	for _, m := range machines {
+		if filter.Matches(m) {
+			filtered = append(filtered, m)
+		}
+	}
+
+	sort.Slice(filtered, func(i, j int) bool { return filtered[i].ID < filtered[j].ID })
+
+	return filtered, nil
+}
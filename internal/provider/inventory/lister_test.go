@@ -0,0 +1,63 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package inventory
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type fakeLister struct {
+	machines []Machine
+	err      error
+}
+
+func (f fakeLister) List(context.Context) ([]Machine, error) { return f.machines, f.err }
+func (f fakeLister) Describe(context.Context, string) (Machine, error) {
+	return Machine{}, errors.New("not implemented")
+}
+
+func TestList(t *testing.T) {
+	lister := fakeLister{machines: []Machine{
+		{ID: "m3", PowerState: "on"},
+		{ID: "m1", PowerState: "on"},
+		{ID: "m2", PowerState: "off"},
+	}}
+
+	got, err := List(context.Background(), lister, Filter{PowerState: "on"})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	want := []Machine{{ID: "m1", PowerState: "on"}, {ID: "m3", PowerState: "on"}}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("List() = %+v, want %+v (sorted by ID, filtered by power state)", got, want)
+	}
+}
+
+func TestListPropagatesListerError(t *testing.T) {
+	wantErr := errors.New("boom")
+
+	_, err := List(context.Background(), fakeLister{err: wantErr}, Filter{})
+	if !errors.Is(err, wantErr) {
+		t.Errorf("List() error = %v, want to wrap %v", err, wantErr)
+	}
+}
+
+func TestListNoMatches(t *testing.T) {
+	lister := fakeLister{machines: []Machine{{ID: "m1", PowerState: "off"}}}
+
+	got, err := List(context.Background(), lister, Filter{PowerState: "on"})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if len(got) != 0 {
+		t.Errorf("List() = %+v, want empty", got)
+	}
+}
@@ -0,0 +1,68 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package machineconfig implements per-machine overrides of the provider's otherwise
+// process-global boot and power management options, loaded from a --machine-config-file and
+// hot-reloaded on edit.
+//
+// Each subsystem that currently reads an option once at startup (the iPXE handler, the PXE/IPMI
+// boot-mode selection, the Redfish client factory) should instead call Store.For(machine) at the
+// point it needs the option, so that config file edits take effect without a provider restart.
+// Those subsystems are not part of this source tree, so as of this package, Store.For has no
+// caller yet; the provider only loads and hot-reloads the file (see Store.Watch), without any
+// boot/power decision consulting it.
+package machineconfig
+
+import (
+	"time"
+
+	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider/bmc"
+)
+
+// Selector matches a subset of machines that an Override applies to. Exactly one of its fields
+// should be set.
+type Selector struct {
+	// Label matches machines carrying this "key=value" label.
+	Label string `yaml:"label,omitempty"`
+	// ID matches a single machine by ID.
+	ID string `yaml:"id,omitempty"`
+	// Manufacturer matches machines whose detected BMC vendor manufacturer string contains this
+	// value, case-insensitively.
+	Manufacturer string `yaml:"manufacturer,omitempty"`
+}
+
+// Override is the set of process-global options this package allows overriding per machine. A
+// zero value for a field means "do not override", i.e. fall back to the process-global flag.
+type Override struct {
+	BootMethod         bmc.BootMethod `yaml:"bootMethod,omitempty"`
+	BootFromDiskMethod string         `yaml:"bootFromDiskMethod,omitempty"`
+	IPMIPXEBootMode    string         `yaml:"ipmiPxeBootMode,omitempty"`
+	BMCDriver          bmc.VendorID   `yaml:"bmcDriver,omitempty"`
+	WipeWithZeroes     *bool          `yaml:"wipeWithZeroes,omitempty"`
+	MinRebootInterval  *time.Duration `yaml:"minRebootInterval,omitempty"`
+
+	Redfish RedfishOverride `yaml:"redfish,omitempty"`
+}
+
+// RedfishOverride overrides the process-global --redfish-* flags for matching machines.
+type RedfishOverride struct {
+	UseAlways                 *bool `yaml:"useAlways,omitempty"`
+	UseWhenAvailable          *bool `yaml:"useWhenAvailable,omitempty"`
+	UseHTTPS                  *bool `yaml:"useHttps,omitempty"`
+	InsecureSkipTLSVerify     *bool `yaml:"insecureSkipTlsVerify,omitempty"`
+	Port                      *int  `yaml:"port,omitempty"`
+	SetBootSourceOverrideMode *bool `yaml:"setBootSourceOverrideMode,omitempty"`
+}
+
+// Rule binds a Selector to the Override applied to machines it matches. Rules are evaluated in
+// file order; the first matching rule for a given field wins.
+type Rule struct {
+	Selector Selector `yaml:"selector"`
+	Override Override `yaml:"override"`
+}
+
+// File is the top-level shape of --machine-config-file.
+type File struct {
+	Rules []Rule `yaml:"rules"`
+}
@@ -0,0 +1,108 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package machineconfig
+
+import "strings"
+
+// Machine is the subset of machine identity a Selector is matched against.
+type Machine struct {
+	ID           string
+	Labels       map[string]string
+	Manufacturer string
+}
+
+// Matches reports whether s selects m.
+func (s Selector) Matches(m Machine) bool {
+	switch {
+	case s.ID != "":
+		return s.ID == m.ID
+	case s.Label != "":
+		key, value, ok := strings.Cut(s.Label, "=")
+		if !ok {
+			return false
+		}
+
+		got, present := m.Labels[key]
+
+		return present && got == value
+	case s.Manufacturer != "":
+		return strings.Contains(strings.ToLower(m.Manufacturer), strings.ToLower(s.Manufacturer))
+	default:
+		return false
+	}
+}
+
+// Resolve merges the Override of every Rule in f that matches m, in file order, with earlier
+// rules taking precedence over later ones on a per-field basis.
+func (f File) Resolve(m Machine) Override {
+	var merged Override
+
+	for _, rule := range f.Rules {
+		if rule.Selector.Matches(m) {
+			merged = mergeOverride(merged, rule.Override)
+		}
+	}
+
+	return merged
+}
+
+// mergeOverride returns dst with every unset field filled in from src.
+func mergeOverride(dst, src Override) Override {
+	if dst.BootMethod == "" {
+		dst.BootMethod = src.BootMethod
+	}
+
+	if dst.BootFromDiskMethod == "" {
+		dst.BootFromDiskMethod = src.BootFromDiskMethod
+	}
+
+	if dst.IPMIPXEBootMode == "" {
+		dst.IPMIPXEBootMode = src.IPMIPXEBootMode
+	}
+
+	if dst.BMCDriver == "" {
+		dst.BMCDriver = src.BMCDriver
+	}
+
+	if dst.WipeWithZeroes == nil {
+		dst.WipeWithZeroes = src.WipeWithZeroes
+	}
+
+	if dst.MinRebootInterval == nil {
+		dst.MinRebootInterval = src.MinRebootInterval
+	}
+
+	dst.Redfish = mergeRedfishOverride(dst.Redfish, src.Redfish)
+
+	return dst
+}
+
+func mergeRedfishOverride(dst, src RedfishOverride) RedfishOverride {
+	if dst.UseAlways == nil {
+		dst.UseAlways = src.UseAlways
+	}
+
+	if dst.UseWhenAvailable == nil {
+		dst.UseWhenAvailable = src.UseWhenAvailable
+	}
+
+	if dst.UseHTTPS == nil {
+		dst.UseHTTPS = src.UseHTTPS
+	}
+
+	if dst.InsecureSkipTLSVerify == nil {
+		dst.InsecureSkipTLSVerify = src.InsecureSkipTLSVerify
+	}
+
+	if dst.Port == nil {
+		dst.Port = src.Port
+	}
+
+	if dst.SetBootSourceOverrideMode == nil {
+		dst.SetBootSourceOverrideMode = src.SetBootSourceOverrideMode
+	}
+
+	return dst
+}
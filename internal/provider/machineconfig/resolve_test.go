@@ -0,0 +1,106 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package machineconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/provider/bmc"
+)
+
+func TestSelectorMatches(t *testing.T) {
+	m := Machine{ID: "machine-1", Labels: map[string]string{"rack": "a"}, Manufacturer: "Dell Inc."}
+
+	tests := []struct {
+		name     string
+		selector Selector
+		want     bool
+	}{
+		{"matches by id", Selector{ID: "machine-1"}, true},
+		{"rejects a different id", Selector{ID: "machine-2"}, false},
+		{"matches by label", Selector{Label: "rack=a"}, true},
+		{"rejects a mismatched label value", Selector{Label: "rack=b"}, false},
+		{"rejects a missing label key", Selector{Label: "row=1"}, false},
+		{"malformed label never matches", Selector{Label: "rack"}, false},
+		{"matches manufacturer case-insensitively", Selector{Manufacturer: "dell"}, true},
+		{"rejects an unrelated manufacturer", Selector{Manufacturer: "supermicro"}, false},
+		{"empty selector matches nothing", Selector{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.selector.Matches(m); got != tt.want {
+				t.Errorf("Selector.Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileResolvePrecedence(t *testing.T) {
+	wipeTrue := true
+	wipeFalse := false
+	interval := 5 * time.Minute
+
+	file := File{Rules: []Rule{
+		{
+			Selector: Selector{Manufacturer: "dell"},
+			Override: Override{BootMethod: bmc.BootMethodVirtualMedia, WipeWithZeroes: &wipeTrue},
+		},
+		{
+			// A later, more specific rule must not override fields the earlier rule already set.
+			Selector: Selector{ID: "machine-1"},
+			Override: Override{BootMethod: bmc.BootMethodPXE, WipeWithZeroes: &wipeFalse, MinRebootInterval: &interval},
+		},
+	}}
+
+	got := file.Resolve(Machine{ID: "machine-1", Manufacturer: "Dell Inc."})
+
+	if got.BootMethod != bmc.BootMethodVirtualMedia {
+		t.Errorf("Resolve().BootMethod = %q, want %q (first matching rule wins)", got.BootMethod, bmc.BootMethodVirtualMedia)
+	}
+
+	if got.WipeWithZeroes == nil || *got.WipeWithZeroes != true {
+		t.Errorf("Resolve().WipeWithZeroes = %v, want true (first matching rule wins)", got.WipeWithZeroes)
+	}
+
+	if got.MinRebootInterval == nil || *got.MinRebootInterval != interval {
+		t.Errorf("Resolve().MinRebootInterval = %v, want %v (only set by the second rule)", got.MinRebootInterval, interval)
+	}
+}
+
+func TestMergeOverride(t *testing.T) {
+	port := 8443
+	useHTTPSTrue := true
+
+	dst := Override{
+		BootMethod: bmc.BootMethodPXE,
+		Redfish:    RedfishOverride{UseHTTPS: &useHTTPSTrue},
+	}
+
+	src := Override{
+		BootMethod:         bmc.BootMethodIPXE,
+		BootFromDiskMethod: "ipxe-exit",
+		Redfish:            RedfishOverride{Port: &port},
+	}
+
+	merged := mergeOverride(dst, src)
+
+	if merged.BootMethod != bmc.BootMethodPXE {
+		t.Errorf("mergeOverride().BootMethod = %q, want %q (dst field already set)", merged.BootMethod, bmc.BootMethodPXE)
+	}
+
+	if merged.BootFromDiskMethod != "ipxe-exit" {
+		t.Errorf("mergeOverride().BootFromDiskMethod = %q, want %q (filled in from src)", merged.BootFromDiskMethod, "ipxe-exit")
+	}
+
+	if merged.Redfish.UseHTTPS == nil || *merged.Redfish.UseHTTPS != true {
+		t.Errorf("mergeOverride().Redfish.UseHTTPS = %v, want true (dst field already set)", merged.Redfish.UseHTTPS)
+	}
+
+	if merged.Redfish.Port == nil || *merged.Redfish.Port != port {
+		t.Errorf("mergeOverride().Redfish.Port = %v, want %v (filled in from src)", merged.Redfish.Port, port)
+	}
+}
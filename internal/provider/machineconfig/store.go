@@ -0,0 +1,111 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package machineconfig
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// Store holds the current File loaded from --machine-config-file, reloading it whenever the file
+// changes on disk so that edits take effect without restarting the provider.
+type Store struct {
+	path string
+	file atomic.Pointer[File]
+}
+
+// NewStore loads path and returns a Store serving it. The returned Store does not watch path for
+// changes until Watch is called.
+func NewStore(path string) (*Store, error) {
+	s := &Store{path: path}
+
+	if err := s.reload(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// For returns the resolved Override for m, merging every matching rule in the current file.
+func (s *Store) For(m Machine) Override {
+	return s.file.Load().Resolve(m)
+}
+
+// Watch watches path for changes, reloading the Store whenever the file is written, until ctx is
+// canceled. Reload errors are logged and the previous, still-valid configuration is kept in use.
+//
+// The parent directory is watched rather than the file itself, since editors and config
+// management tools commonly replace a config file by writing a temporary file and renaming it
+// over the target; watching the file's original inode would silently stop delivering events
+// once that rename happens.
+func (s *Store) Watch(ctx context.Context, logger *zap.Logger) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create machine config file watcher: %w", err)
+	}
+
+	defer watcher.Close() //nolint:errcheck
+
+	if err = watcher.Add(filepath.Dir(s.path)); err != nil {
+		return fmt.Errorf("failed to watch machine config file %q: %w", s.path, err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			if filepath.Clean(event.Name) != filepath.Clean(s.path) {
+				continue
+			}
+
+			if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Rename) {
+				continue
+			}
+
+			if err = s.reload(); err != nil {
+				logger.Error("failed to reload machine config file, keeping previous configuration", zap.Error(err))
+
+				continue
+			}
+
+			logger.Info("reloaded machine config file", zap.String("path", s.path))
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+
+			logger.Error("machine config file watcher error", zap.Error(err))
+		}
+	}
+}
+
+func (s *Store) reload() error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to read machine config file %q: %w", s.path, err)
+	}
+
+	var file File
+
+	if err = yaml.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse machine config file %q: %w", s.path, err)
+	}
+
+	s.file.Store(&file)
+
+	return nil
+}
@@ -0,0 +1,24 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package observability
+
+import "go.opentelemetry.io/otel/attribute"
+
+// Span attribute keys identifying a machine, so that the BMC and virtual-media spans that touch a
+// single machine can be correlated.
+const (
+	MachineIDKey  = attribute.Key("machine.id")
+	BMCVendorKey  = attribute.Key("bmc.vendor")
+	BootMethodKey = attribute.Key("boot.method")
+)
+
+// MachineAttributes returns the common set of span attributes identifying a machine.
+func MachineAttributes(machineID, bmcVendor, bootMethod string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		MachineIDKey.String(machineID),
+		BMCVendorKey.String(bmcVendor),
+		BootMethodKey.String(bootMethod),
+	}
+}
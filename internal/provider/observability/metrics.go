@@ -0,0 +1,89 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package observability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics holds the provider's Prometheus collectors.
+//
+// PowerOpDuration is recorded by the BMC/virtual-media power operations in this tree (see
+// virtualmedia.Booter.timeOp). IPXERequestsTotal, DHCPOffersTotal and RebootThrottled are
+// registered here for the iPXE handler, DHCP proxy and reconcile loop respectively to increment
+// once those subsystems are wired to Metrics; they are not incremented by any code in this tree
+// today.
+type Metrics struct {
+	PowerOpDuration   *prometheus.HistogramVec
+	IPXERequestsTotal *prometheus.CounterVec
+	DHCPOffersTotal   prometheus.Counter
+	RebootThrottled   prometheus.Counter
+}
+
+// NewMetrics registers the provider's metrics collectors and returns them.
+func NewMetrics(registerer prometheus.Registerer) *Metrics {
+	factory := promauto.With(registerer)
+
+	return &Metrics{
+		PowerOpDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "provider_power_op_duration_seconds",
+			Help: "Duration of BMC power management operations.",
+		}, []string{"op", "vendor", "result"}),
+		IPXERequestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Name: "provider_ipxe_requests_total",
+			Help: "Total number of iPXE script requests served.",
+		}, []string{"outcome"}),
+		DHCPOffersTotal: factory.NewCounter(prometheus.CounterOpts{
+			Name: "provider_dhcp_proxy_offers_total",
+			Help: "Total number of DHCP proxy offers sent.",
+		}),
+		RebootThrottled: factory.NewCounter(prometheus.CounterOpts{
+			Name: "provider_reboot_throttled_total",
+			Help: "Total number of reboots throttled by --min-reboot-interval.",
+		}),
+	}
+}
+
+// ServeMetrics starts an HTTP server exposing the Prometheus "/metrics" endpoint on
+// listenAddress, blocking until ctx is canceled.
+func ServeMetrics(ctx context.Context, listenAddress string, registerer *prometheus.Registry) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registerer, promhttp.HandlerOpts{}))
+
+	server := &http.Server{Addr: listenAddress, Handler: mux}
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case <-ctx.Done():
+		return server.Shutdown(context.Background()) //nolint:contextcheck
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+
+		return fmt.Errorf("metrics server failed: %w", err)
+	}
+}
+
+// ValidateListenAddress checks that listenAddress is a well-formed host:port, surfacing a clear
+// error at flag-parsing time rather than when the metrics server fails to start later.
+func ValidateListenAddress(listenAddress string) error {
+	_, _, err := net.SplitHostPort(listenAddress)
+
+	return err
+}
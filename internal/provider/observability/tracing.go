@@ -0,0 +1,96 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package observability provides the provider's OpenTelemetry tracing and Prometheus metrics.
+// Spans and power-operation metrics are recorded by the BMC and virtual-media code
+// (bmc.Resolve, virtualmedia.Booter); the iPXE handler, DHCP proxy and reconcile loop record
+// neither.
+package observability
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/siderolabs/omni-infra-provider-bare-metal/internal/version"
+)
+
+// TracingOptions configures the OTLP exporter used by InitTracing.
+type TracingOptions struct {
+	// Endpoint is the OTLP gRPC collector endpoint, e.g. "otel-collector:4317". Tracing is disabled
+	// if empty.
+	Endpoint string
+	// Headers are additional gRPC metadata headers sent with every export, as "key=value" pairs.
+	Headers []string
+	// SampleRatio is the fraction of traces sampled, in [0, 1].
+	SampleRatio float64
+}
+
+// InitTracing configures the global OpenTelemetry tracer provider from opts and returns a
+// shutdown function that must be called before the process exits to flush pending spans.
+//
+// If opts.Endpoint is empty, tracing is left disabled and Tracer returns a no-op tracer.
+func InitTracing(ctx context.Context, opts TracingOptions) (shutdown func(context.Context) error, err error) {
+	if opts.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	headers, err := parseHeaders(opts.Headers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse --otlp-headers: %w", err)
+	}
+
+	exporter, err := otlptracegrpc.New(ctx,
+		otlptracegrpc.WithEndpoint(opts.Endpoint),
+		otlptracegrpc.WithHeaders(headers),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.New(ctx, resource.WithAttributes(
+		semconv.ServiceName(version.Name),
+		semconv.ServiceVersion(version.Tag),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OTel resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown, nil
+}
+
+// Tracer returns the provider's tracer, to be used by every instrumented subsystem.
+func Tracer() trace.Tracer {
+	return otel.Tracer(version.Name)
+}
+
+func parseHeaders(raw []string) (map[string]string, error) {
+	headers := make(map[string]string, len(raw))
+
+	for _, h := range raw {
+		k, v, ok := strings.Cut(h, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid header %q, expected key=value", h)
+		}
+
+		headers[k] = v
+	}
+
+	return headers, nil
+}
@@ -0,0 +1,132 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package rbac
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// setMachineIDRequest is a fake request message implementing ResourceIdentifier, standing in for
+// a generated request type like SetPowerStateRequest.
+type setMachineIDRequest struct{ machineID string }
+
+func (r setMachineIDRequest) GetMachineId() string { return r.machineID }
+
+func mTLSContext(commonName string, dnsNames ...string) context.Context {
+	cert := &x509.Certificate{DNSNames: dnsNames}
+	cert.Subject.CommonName = commonName
+
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}},
+	})
+}
+
+func bearerTokenContext(t *testing.T, issuer *TokenIssuer, subject string, role Role) context.Context {
+	t.Helper()
+
+	token := issuer.Issue(subject, role, time.Hour)
+
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func testInterceptors(enforce bool) *Interceptors {
+	policy := &Policy{
+		Subjects: map[string]Role{"agent-1.example": RoleAgent},
+		Roles: map[Role][]Grant{
+			RoleAgent:     {{Verb: "GetIPXEScript", Resource: "*"}},
+			RolePowerOnly: {{Verb: "SetPowerState", Resource: "machine-1"}},
+		},
+	}
+
+	return &Interceptors{Policy: policy, Enforce: enforce, Logger: zap.NewNop()}
+}
+
+func TestAuthorizeUsesPolicyForMTLSIdentity(t *testing.T) {
+	interceptors := testInterceptors(true)
+
+	ctx := mTLSContext("", "agent-1.example")
+
+	if err := interceptors.authorize(ctx, "/omni.infra.Provider/GetIPXEScript", "*"); err != nil {
+		t.Errorf("authorize() for a policy-bound mTLS subject = %v, want nil", err)
+	}
+}
+
+func TestAuthorizeRejectsUnboundMTLSIdentity(t *testing.T) {
+	interceptors := testInterceptors(true)
+
+	ctx := mTLSContext("", "unbound.example")
+
+	if err := interceptors.authorize(ctx, "/omni.infra.Provider/GetIPXEScript", "*"); err == nil {
+		t.Error("authorize() for a subject with no bound role = nil, want error")
+	}
+}
+
+func TestAuthorizeTrustsTheTokensSignedRoleNotPolicySubjects(t *testing.T) {
+	issuer := &TokenIssuer{Secret: []byte("test-secret")}
+	DefaultTokenIssuer = issuer
+
+	t.Cleanup(func() { DefaultTokenIssuer = nil })
+
+	interceptors := testInterceptors(true)
+
+	// "agent-1.example" is bound to RoleAgent in Policy.Subjects, but this token was issued with
+	// RolePowerOnly: the token's signed role must win, not the policy's subject mapping.
+	ctx := bearerTokenContext(t, issuer, "agent-1.example", RolePowerOnly)
+
+	if err := interceptors.authorize(ctx, "/omni.infra.Provider/SetPowerState", "machine-1"); err != nil {
+		t.Errorf("authorize() = %v, want nil (token-signed role should be authoritative)", err)
+	}
+
+	if err := interceptors.authorize(ctx, "/omni.infra.Provider/GetIPXEScript", "*"); err == nil {
+		t.Error("authorize() for a verb not granted to the token's signed role = nil, want error")
+	}
+}
+
+func TestAuthorizeEnforcesResourceScopeFromTheRequestNotMetadata(t *testing.T) {
+	issuer := &TokenIssuer{Secret: []byte("test-secret")}
+	DefaultTokenIssuer = issuer
+
+	t.Cleanup(func() { DefaultTokenIssuer = nil })
+
+	interceptors := testInterceptors(true)
+	ctx := bearerTokenContext(t, issuer, "caller", RolePowerOnly)
+
+	if err := interceptors.authorize(ctx, "/omni.infra.Provider/SetPowerState", resourceFromRequest(setMachineIDRequest{machineID: "machine-1"})); err != nil {
+		t.Errorf("authorize() for the grant's own machine = %v, want nil", err)
+	}
+
+	if err := interceptors.authorize(ctx, "/omni.infra.Provider/SetPowerState", resourceFromRequest(setMachineIDRequest{machineID: "machine-2"})); err == nil {
+		t.Error("authorize() for a machine outside the grant = nil, want error")
+	}
+}
+
+func TestAuthorizeNotEnforcedLogsButAllows(t *testing.T) {
+	interceptors := testInterceptors(false)
+
+	ctx := mTLSContext("", "unbound.example")
+
+	if err := interceptors.authorize(ctx, "/omni.infra.Provider/GetIPXEScript", "*"); err != nil {
+		t.Errorf("authorize() with enforce=false = %v, want nil", err)
+	}
+}
+
+func TestResourceFromRequest(t *testing.T) {
+	if got := resourceFromRequest(setMachineIDRequest{machineID: "machine-9"}); got != "machine-9" {
+		t.Errorf("resourceFromRequest() = %q, want %q", got, "machine-9")
+	}
+
+	if got := resourceFromRequest(struct{}{}); got != "*" {
+		t.Errorf("resourceFromRequest() for a request with no machine ID = %q, want %q", got, "*")
+	}
+}
@@ -0,0 +1,90 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package rbac implements role-based access control for the provider's gRPC/HTTP API, on top of
+// the identity established by mTLS (subject SANs) or a bearer token issued via "token issue".
+package rbac
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Role is a named bundle of verb/resource grants.
+type Role string
+
+// Built-in role names. A role carries no grants of its own: what it may call is exactly the
+// grants the policy's Roles map lists for it.
+const (
+	// RoleAgent is the role for Talos agents, which report status and fetch their own boot assets.
+	RoleAgent Role = "agent"
+	// RoleAdmin is the role for operators administering the provider.
+	RoleAdmin Role = "admin"
+	// RoleReadOnly is the role for callers of read-only methods (e.g. list/describe).
+	RoleReadOnly Role = "read-only"
+	// RolePowerOnly is the role for callers of power-management methods.
+	RolePowerOnly Role = "power-only"
+)
+
+// Verb identifies an operation a role may or may not be allowed to perform, typically a gRPC
+// method name, e.g. "SetPowerState" or "GetIPXEScript".
+type Verb string
+
+// Grant is a single verb/resource pattern a Role is allowed to invoke. Resource is either "*"
+// (any resource) or a machine ID.
+type Grant struct {
+	Verb     Verb   `yaml:"verb"`
+	Resource string `yaml:"resource"`
+}
+
+// Policy maps subjects to roles, and roles to the grants they hold.
+//
+// Subjects only applies to callers authenticated via mTLS SAN; a caller authenticated via a
+// bearer token is bound to the role the token's own signature carries (see "token issue --role"),
+// not to an entry in Subjects, so that the role a token was issued with cannot silently change by
+// editing the policy file independently of reissuing the token.
+type Policy struct {
+	Subjects map[string]Role  `yaml:"subjects"`
+	Roles    map[Role][]Grant `yaml:"roles"`
+}
+
+// LoadPolicyFile reads and parses a --rbac-policy-file.
+func LoadPolicyFile(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read RBAC policy file %q: %w", path, err)
+	}
+
+	var policy Policy
+
+	if err = yaml.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse RBAC policy file %q: %w", path, err)
+	}
+
+	return &policy, nil
+}
+
+// RoleFor returns the Role bound to subject, and whether one is configured.
+func (p *Policy) RoleFor(subject string) (Role, bool) {
+	role, ok := p.Subjects[subject]
+
+	return role, ok
+}
+
+// Allows reports whether role is granted verb on resource.
+func (p *Policy) Allows(role Role, verb Verb, resource string) bool {
+	for _, grant := range p.Roles[role] {
+		if grant.Verb != verb {
+			continue
+		}
+
+		if grant.Resource == "*" || grant.Resource == resource {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,51 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package rbac
+
+import "testing"
+
+func TestPolicyRoleFor(t *testing.T) {
+	policy := &Policy{Subjects: map[string]Role{"agent-1.example": RoleAgent}}
+
+	if role, ok := policy.RoleFor("agent-1.example"); !ok || role != RoleAgent {
+		t.Errorf("RoleFor(%q) = (%q, %v), want (%q, true)", "agent-1.example", role, ok, RoleAgent)
+	}
+
+	if _, ok := policy.RoleFor("unknown.example"); ok {
+		t.Error("RoleFor() for an unbound subject = true, want false")
+	}
+}
+
+func TestPolicyAllows(t *testing.T) {
+	policy := &Policy{
+		Roles: map[Role][]Grant{
+			RoleAdmin:     {{Verb: "SetPowerState", Resource: "*"}},
+			RolePowerOnly: {{Verb: "SetPowerState", Resource: "machine-1"}},
+			RoleReadOnly:  {{Verb: "GetIPXEScript", Resource: "*"}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		role     Role
+		verb     Verb
+		resource string
+		want     bool
+	}{
+		{"admin wildcard grants any resource", RoleAdmin, "SetPowerState", "machine-7", true},
+		{"power-only matches its scoped machine", RolePowerOnly, "SetPowerState", "machine-1", true},
+		{"power-only rejects a different machine", RolePowerOnly, "SetPowerState", "machine-2", false},
+		{"read-only lacks the verb entirely", RoleReadOnly, "SetPowerState", "machine-1", false},
+		{"unknown role has no grants", Role("unknown"), "SetPowerState", "machine-1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.Allows(tt.role, tt.verb, tt.resource); got != tt.want {
+				t.Errorf("Allows(%q, %q, %q) = %v, want %v", tt.role, tt.verb, tt.resource, got, tt.want)
+			}
+		})
+	}
+}
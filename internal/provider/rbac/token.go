@@ -0,0 +1,115 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package rbac
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TokenIssuer issues and verifies bearer tokens binding a subject to a role, for agents and
+// operators that cannot present an mTLS client certificate.
+type TokenIssuer struct {
+	// Secret is used to HMAC-sign issued tokens. It must match between issuance and verification.
+	Secret []byte
+}
+
+// tokenFieldSeparator separates the subject, role, expiry and signature fields of a token. The
+// subject and role fields are base64url-encoded before joining, since the separator must not
+// collide with a character either field can legitimately contain — an mTLS-style FQDN subject
+// (see identityFromContext) contains dots, so a bare subject/role would break the field count
+// Verify expects.
+const tokenFieldSeparator = "."
+
+// Issue returns a bearer token binding subject to role, valid until ttl elapses.
+func (i TokenIssuer) Issue(subject string, role Role, ttl time.Duration) string {
+	expiry := time.Now().Add(ttl).Unix()
+	sig := i.sign(subject, role, expiry)
+
+	fields := []string{encodeTokenField(subject), encodeTokenField(string(role)), strconv.FormatInt(expiry, 10), sig}
+
+	return strings.Join(fields, tokenFieldSeparator)
+}
+
+// Verify parses and validates a bearer token previously issued by Issue, returning the bound
+// subject and role.
+func (i TokenIssuer) Verify(token string) (subject string, role Role, err error) {
+	fields := strings.Split(token, tokenFieldSeparator)
+	if len(fields) != 4 {
+		return "", "", fmt.Errorf("malformed token")
+	}
+
+	subject, err = decodeTokenField(fields[0])
+	if err != nil {
+		return "", "", fmt.Errorf("malformed token subject: %w", err)
+	}
+
+	roleStr, err := decodeTokenField(fields[1])
+	if err != nil {
+		return "", "", fmt.Errorf("malformed token role: %w", err)
+	}
+
+	expiry, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return "", "", fmt.Errorf("malformed token expiry: %w", err)
+	}
+
+	if time.Now().Unix() > expiry {
+		return "", "", fmt.Errorf("token expired")
+	}
+
+	role = Role(roleStr)
+	sig := fields[3]
+
+	if !hmac.Equal([]byte(sig), []byte(i.sign(subject, role, expiry))) {
+		return "", "", fmt.Errorf("invalid token signature")
+	}
+
+	return subject, role, nil
+}
+
+// encodeTokenField and decodeTokenField keep a token field's content from colliding with
+// tokenFieldSeparator; base64.RawURLEncoding's alphabet never contains a dot.
+func encodeTokenField(field string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(field))
+}
+
+func decodeTokenField(field string) (string, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(field)
+	if err != nil {
+		return "", err
+	}
+
+	return string(decoded), nil
+}
+
+func (i TokenIssuer) sign(subject string, role Role, expiry int64) string {
+	mac := hmac.New(sha256.New, i.Secret)
+	fmt.Fprintf(mac, "%s:%s:%d", subject, role, expiry)
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// DefaultTokenIssuer is the package-level issuer used by the interceptors to validate bearer
+// tokens presented without an mTLS certificate. It must be set by the provider at startup before
+// any requests are served.
+var DefaultTokenIssuer *TokenIssuer
+
+// subjectFromToken verifies token against DefaultTokenIssuer, returning the subject and the role
+// the token's own signature binds it to.
+func subjectFromToken(token string) (string, Role, bool) {
+	if DefaultTokenIssuer == nil || token == "" {
+		return "", "", false
+	}
+
+	subject, role, err := DefaultTokenIssuer.Verify(token)
+
+	return subject, role, err == nil
+}
@@ -0,0 +1,80 @@
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package rbac
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenIssuerIssueVerify(t *testing.T) {
+	issuer := TokenIssuer{Secret: []byte("test-secret")}
+
+	tests := []struct {
+		name    string
+		subject string
+		role    Role
+	}{
+		{"simple subject", "caller", RoleAdmin},
+		// An mTLS-style FQDN subject, exactly what identityFromContext extracts from a
+		// certificate's DNSNames, contains dots and must not be split on.
+		{"dotted FQDN subject", "agent-1.example", RolePowerOnly},
+		{"subject with multiple dots", "agent-1.rack-a.example.com", RoleReadOnly},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := issuer.Issue(tt.subject, tt.role, time.Hour)
+
+			subject, role, err := issuer.Verify(token)
+			if err != nil {
+				t.Fatalf("Verify() = %v, want nil", err)
+			}
+
+			if subject != tt.subject {
+				t.Errorf("Verify() subject = %q, want %q", subject, tt.subject)
+			}
+
+			if role != tt.role {
+				t.Errorf("Verify() role = %q, want %q", role, tt.role)
+			}
+		})
+	}
+}
+
+func TestTokenIssuerVerifyRejects(t *testing.T) {
+	issuer := TokenIssuer{Secret: []byte("test-secret")}
+
+	t.Run("expired token", func(t *testing.T) {
+		token := issuer.Issue("caller", RoleAdmin, -time.Hour)
+
+		if _, _, err := issuer.Verify(token); err == nil {
+			t.Error("Verify() for an expired token = nil, want error")
+		}
+	})
+
+	t.Run("tampered signature", func(t *testing.T) {
+		token := issuer.Issue("caller", RoleAdmin, time.Hour)
+
+		if _, _, err := issuer.Verify(token + "x"); err == nil {
+			t.Error("Verify() for a tampered token = nil, want error")
+		}
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		token := issuer.Issue("caller", RoleAdmin, time.Hour)
+		other := TokenIssuer{Secret: []byte("other-secret")}
+
+		if _, _, err := other.Verify(token); err == nil {
+			t.Error("Verify() with a different issuer's secret = nil, want error")
+		}
+	})
+
+	t.Run("malformed token", func(t *testing.T) {
+		if _, _, err := issuer.Verify("not-a-token"); err == nil {
+			t.Error("Verify() for a malformed token = nil, want error")
+		}
+	})
+}